@@ -17,10 +17,8 @@ limitations under the License.
 package main
 
 import (
-	"fmt"
 	"os"
 
-	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/alikhil/kubectl-find/pkg/cmd"
@@ -41,17 +39,10 @@ func main() {
 	//nolint:reassign // flags are shared
 	pflag.CommandLine = flags
 
-	root := cmd.NewCmdFind(genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
-
-	root.AddCommand(&cobra.Command{
-		Use:   "version",
-		Short: "Print the version number of kubectl-find",
-		Run: func(_ *cobra.Command, _ []string) {
-			//nolint:forbidigo // this is a CLI tool, so printing version info is acceptable
-			fmt.Printf("version: %s\ncommit: %s\ndate: %s\n", version, commit, date)
-			os.Exit(0)
-		},
-	})
+	streams := genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
+	root := cmd.NewCmdFind(streams)
+
+	root.AddCommand(cmd.NewCmdVersion(streams, cmd.BuildInfo{Version: version, Commit: commit, Date: date}))
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
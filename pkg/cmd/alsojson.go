@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// jsonFile is the file given by --also-json. Handlers are created with it during validation,
+// but the file is only created by Run, so a rejected command line does not leave an empty file behind.
+// Writes are serialized, because contexts are searched concurrently.
+type jsonFile struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (f *jsonFile) open() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create --also-json file: %w", err)
+	}
+	f.file = file
+	return nil
+}
+
+func (f *jsonFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, errors.New("--also-json file is not open")
+	}
+	return f.file.Write(p)
+}
+
+func (f *jsonFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close --also-json file: %w", err)
+	}
+	return nil
+}
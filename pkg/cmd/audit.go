@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/filterspec"
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+)
+
+// auditRecord is the JSON document written to --audit-log after the run.
+type auditRecord struct {
+	StartedAt     time.Time              `json:"startedAt"`
+	FinishedAt    time.Time              `json:"finishedAt"`
+	Contexts      []string               `json:"contexts"`
+	User          string                 `json:"user,omitempty"`
+	Namespace     string                 `json:"namespace,omitempty"`
+	AllNamespaces bool                   `json:"allNamespaces,omitempty"`
+	Resource      string                 `json:"resource"`
+	Filters       *filterspec.FilterSpec `json:"filters"`
+	Action        string                 `json:"action"`
+	Items         []handlers.AuditItem   `json:"items"`
+	Error         string                 `json:"error,omitempty"`
+}
+
+// auditUser returns the user the operation is performed as:
+// the impersonated user, the user given by --user or the user of the current context.
+func (o *FindOptions) auditUser() string {
+	if o.configFlags.Impersonate != nil && *o.configFlags.Impersonate != "" {
+		return *o.configFlags.Impersonate
+	}
+	if o.configFlags.AuthInfoName != nil && *o.configFlags.AuthInfoName != "" {
+		return *o.configFlags.AuthInfoName
+	}
+	if kubeContext, exists := o.rawConfig.Contexts[o.currentContext]; exists {
+		return kubeContext.AuthInfo
+	}
+	return ""
+}
+
+// writeAuditLog writes the audit record of the run that started at startedAt and ended with runErr to --audit-log.
+func (o *FindOptions) writeAuditLog(startedAt time.Time, runErr error) error {
+	contexts := o.targetContexts
+	if len(contexts) == 0 {
+		contexts = []string{o.currentContext}
+	}
+	action := o.options.Action.String()
+	if o.options.Evict {
+		action = "evict"
+	}
+	record := auditRecord{
+		StartedAt:     startedAt,
+		FinishedAt:    time.Now(),
+		Contexts:      contexts,
+		User:          o.auditUser(),
+		Namespace:     o.options.Namespace,
+		AllNamespaces: o.allNamespaces,
+		Resource:      o.resourceType.GroupVersionResource.String(),
+		Filters:       o.filterSpec(),
+		Action:        action,
+		Items:         o.options.Audit.Items(),
+	}
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log: %w", err)
+	}
+	if err = os.WriteFile(o.auditLog, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestWriteAuditLog(t *testing.T) {
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.auditLog = filepath.Join(t.TempDir(), "audit.json")
+	o.currentContext = "prod"
+	o.rawConfig = api.Config{Contexts: map[string]*api.Context{"prod": {AuthInfo: "admin"}}}
+	o.searchType = "pods"
+	o.regex = "^web-"
+	o.minAge = "1h"
+	o.flags = pflag.NewFlagSet("find", pflag.ContinueOnError)
+	o.flags.StringP("namespace", "n", "", "")
+	o.flags.StringVar(&o.usesSecret, "uses-secret", "", "")
+	o.flags.BoolVarP(&o.skipConfirm, "skip-confirm", "y", false, "")
+	require.NoError(t, o.flags.Parse([]string{"-n", "shop", "--uses-secret", "tls", "-y"}))
+	o.resourceType = handlers.Resource{GroupVersionResource: handlers.PodType}
+	o.options = handlers.ActionOptions{
+		Namespace: "shop",
+		Action:    handlers.ActionDelete,
+		Audit:     handlers.NewAuditTrail("prod"),
+	}
+	deleted := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop", UID: "uid-1"}}
+	failed := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "shop", UID: "uid-2"}}
+	o.options.Audit.Matched("pod", deleted)
+	o.options.Audit.Matched("pod", failed)
+	o.options.Audit.Done("pod", deleted, nil)
+	o.options.Audit.Done("pod", failed, errors.New("pods \"web-2\" is forbidden"))
+
+	startedAt := time.Now().Add(-time.Second)
+	require.NoError(t, o.writeAuditLog(startedAt, errors.New("failed to delete pod web-2")))
+
+	data, err := os.ReadFile(o.auditLog)
+	require.NoError(t, err)
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(data, &record))
+
+	assert.Equal(t, []any{"prod"}, record["contexts"])
+	assert.Equal(t, "admin", record["user"])
+	assert.Equal(t, "shop", record["namespace"])
+	assert.Equal(t, "/v1, Resource=pods", record["resource"])
+	assert.Equal(t, map[string]any{
+		"type":   "pods",
+		"name":   "^web-",
+		"minAge": "1h",
+		"flags":  map[string]any{"namespace": "shop", "uses-secret": "tls"},
+	}, record["filters"], "all filters given are recorded, but no other flags")
+	assert.Equal(t, "delete", record["action"])
+	assert.Equal(t, "failed to delete pod web-2", record["error"])
+	assert.NotEmpty(t, record["startedAt"])
+	assert.NotEmpty(t, record["finishedAt"])
+
+	items, ok := record["items"].([]any)
+	require.True(t, ok)
+	require.Len(t, items, 2)
+	first, second := items[0].(map[string]any), items[1].(map[string]any)
+	assert.Equal(t, "uid-1", first["uid"])
+	assert.Equal(t, "succeeded", first["outcome"])
+	assert.NotEmpty(t, first["time"])
+	assert.Equal(t, "uid-2", second["uid"])
+	assert.Equal(t, "failed", second["outcome"])
+	assert.Equal(t, `pods "web-2" is forbidden`, second["error"])
+}
+
+func TestAuditUser_Impersonation(t *testing.T) {
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.currentContext = "prod"
+	o.rawConfig = api.Config{Contexts: map[string]*api.Context{"prod": {AuthInfo: "admin"}}}
+	assert.Equal(t, "admin", o.auditUser())
+
+	impersonate := "jane@example.com"
+	o.configFlags.Impersonate = &impersonate
+	assert.Equal(t, "jane@example.com", o.auditUser())
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// defaultMaxConcurrent limits how many contexts are searched at the same time, unless set by --max-concurrent.
+const defaultMaxConcurrent = 4
+
+var errNoReachableContexts = errors.New("unable to search any of the kubeconfig contexts")
+
+// resolveContexts returns the kubeconfig contexts selected by --all-contexts or --contexts.
+// All contexts are returned sorted by name, explicitly listed ones keep their order.
+func (o *FindOptions) resolveContexts() ([]string, error) {
+	if o.allContexts {
+		contextNames := make([]string, 0, len(o.rawConfig.Contexts))
+		for name := range o.rawConfig.Contexts {
+			contextNames = append(contextNames, name)
+		}
+		sort.Strings(contextNames)
+		return contextNames, nil
+	}
+
+	contextNames := make([]string, 0, len(o.contexts))
+	for _, name := range o.contexts {
+		if _, exists := o.rawConfig.Contexts[name]; !exists {
+			return nil, fmt.Errorf("context %q not found in kubeconfig", name)
+		}
+		if !slices.Contains(contextNames, name) {
+			contextNames = append(contextNames, name)
+		}
+	}
+	return contextNames, nil
+}
+
+// contextOutput holds what the search in a single context printed, until all contexts are searched.
+type contextOutput struct {
+	out    bytes.Buffer
+	errOut bytes.Buffer
+	rows   printers.TableRows
+}
+
+// runContexts searches the given kubeconfig contexts and prints results in the same order.
+// Tables of all contexts are rendered as one, with a CONTEXT column when more than one context is searched.
+// Contexts that cannot be searched are reported as warnings.
+func (o *FindOptions) runContexts(ctx context.Context, contextNames []string) error {
+	showContext := len(contextNames) > 1
+	outputs := make([]contextOutput, len(contextNames))
+	errs := make([]error, len(contextNames))
+
+	runConcurrently(len(contextNames), o.maxConcurrent, func(i int) {
+		errs[i] = o.findInContext(ctx, contextNames[i], showContext, &outputs[i])
+	})
+
+	succeeded := 0
+	rows := make([]*printers.TableRows, 0, len(contextNames))
+	for i, name := range contextNames {
+		// warnings are buffered too, so they are not interleaved between contexts searched at the same time
+		if _, err := o.ErrOut.Write(outputs[i].errOut.Bytes()); err != nil {
+			return err
+		}
+		if errs[i] != nil {
+			fmt.Fprintf(o.ErrOut, "Warning: skipping context %q: %v\n", name, errs[i])
+			continue
+		}
+		succeeded++
+		if _, err := o.Out.Write(outputs[i].out.Bytes()); err != nil {
+			return err
+		}
+		rows = append(rows, &outputs[i].rows)
+	}
+	if err := printers.RenderTables(o.Out, rows...); err != nil {
+		return err
+	}
+
+	if succeeded == 0 {
+		return errNoReachableContexts
+	}
+	return nil
+}
+
+// runConcurrently calls run for every index from 0 to n-1, at most limit calls at the same time,
+// and waits for all of them to return.
+func runConcurrently(n, limit int, run func(i int)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, limit)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			run(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// contextConfig returns the REST config of the given kubeconfig context.
+func (o *FindOptions) contextConfig(contextName string) (*rest.Config, error) {
+	config, err := clientcmd.NewNonInteractiveClientConfig(
+		o.rawConfig,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create REST config: %w", err)
+	}
+	return config, nil
+}
+
+// findTargetResource resolves the searched resource type in the first of the target contexts where it is found,
+// and uses that context for validation, so the current context does not need to be reachable.
+func (o *FindOptions) findTargetResource() error {
+	errs := make([]error, 0, len(o.targetContexts))
+	for _, name := range o.targetContexts {
+		config, err := o.contextConfig(name)
+		if err == nil {
+			o.resourceType, err = findResource(config, o.searchType)
+		}
+		if err == nil {
+			o.rest = config
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("context %q: %w", name, err))
+	}
+	return fmt.Errorf("unable to find resource type %q: %w", o.searchType, errors.Join(errs...))
+}
+
+// findInContext runs the find against a single kubeconfig context, writing results to output.
+func (o *FindOptions) findInContext(
+	ctx context.Context,
+	contextName string,
+	showContext bool,
+	output *contextOutput,
+) error {
+	config, err := o.contextConfig(contextName)
+	if err != nil {
+		return err
+	}
+
+	stopDiscovery := o.profiler.Start(handlers.ProfilePhaseDiscovery)
+	resourceType, err := findResource(config, o.searchType)
+	if err != nil {
+		return fmt.Errorf("unable to find resource type %q: %w", o.searchType, err)
+	}
+
+	handlerOptions := o.handlerOptions.WithTableRows(&output.rows)
+	if showContext {
+		handlerOptions = handlerOptions.WithContextName(contextName)
+	}
+	handler, err := o.newHandler(config, resourceType, handlerOptions)
+	if err != nil {
+		return fmt.Errorf("unable to create resource handler for type %s: %w", resourceType.SingularName, err)
+	}
+	stopDiscovery(0)
+
+	options := o.options
+	options.ResourceType = resourceType
+	options.Namespace = o.contextNamespace(contextName)
+	options.FallbackNamespace = o.kubeconfigNamespace(contextName)
+	options.Streams = &genericiooptions.IOStreams{In: o.In, Out: &output.out, ErrOut: &output.errOut}
+	options.Audit = o.options.Audit.ForContext(contextName)
+
+	return handler.HandleAction(ctx, options)
+}
+
+// contextNamespace returns the namespace to search in the given context,
+// falling back to the context's own namespace when none was specified.
+func (o *FindOptions) contextNamespace(contextName string) string {
+	if o.allNamespaces {
+		return ""
+	}
+	if o.namespaceSpecified {
+		return o.userSpecifiedNamespace
+	}
+	return o.kubeconfigNamespace(contextName)
+}
+
+// kubeconfigNamespace returns the namespace of the given context, "default" when it has none.
+func (o *FindOptions) kubeconfigNamespace(contextName string) string {
+	if kubeContext, exists := o.rawConfig.Contexts[contextName]; exists && kubeContext.Namespace != "" {
+		return kubeContext.Namespace
+	}
+	return "default"
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestContextNamespace(t *testing.T) {
+	rawConfig := api.Config{
+		Contexts: map[string]*api.Context{
+			"ctx-a": {Namespace: "ns-a"},
+			"ctx-b": {},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options FindOptions
+		context string
+		want    string
+	}{
+		{
+			name:    "context namespace",
+			context: "ctx-a",
+			want:    "ns-a",
+		},
+		{
+			name:    "default namespace when context has none",
+			context: "ctx-b",
+			want:    "default",
+		},
+		{
+			name:    "specified namespace overrides context namespace",
+			options: FindOptions{userSpecifiedNamespace: "ns-flag", namespaceSpecified: true},
+			context: "ctx-a",
+			want:    "ns-flag",
+		},
+		{
+			name:    "all namespaces",
+			options: FindOptions{allNamespaces: true},
+			context: "ctx-a",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.rawConfig = rawConfig
+			assert.Equal(t, tt.want, tt.options.contextNamespace(tt.context))
+		})
+	}
+}
+
+func TestResolveContexts(t *testing.T) {
+	rawConfig := api.Config{
+		Contexts: map[string]*api.Context{
+			"prod-eu": {},
+			"prod-us": {},
+			"staging": {},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options FindOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "all contexts sorted by name",
+			options: FindOptions{allContexts: true},
+			want:    []string{"prod-eu", "prod-us", "staging"},
+		},
+		{
+			name:    "subset keeps the given order",
+			options: FindOptions{contexts: []string{"prod-us", "prod-eu"}},
+			want:    []string{"prod-us", "prod-eu"},
+		},
+		{
+			name:    "duplicates are searched once",
+			options: FindOptions{contexts: []string{"staging", "staging"}},
+			want:    []string{"staging"},
+		},
+		{
+			name:    "unknown context",
+			options: FindOptions{contexts: []string{"prod-eu", "dev"}},
+			wantErr: `context "dev" not found in kubeconfig`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.rawConfig = rawConfig
+			got, err := tt.options.resolveContexts()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunContexts_UnreachableContexts(t *testing.T) {
+	streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	o := NewFindOptions(streams)
+	o.rawConfig = api.Config{
+		Clusters: map[string]*api.Cluster{
+			"unreachable": {Server: "https://127.0.0.1:1"},
+		},
+		AuthInfos: map[string]*api.AuthInfo{
+			"user": {Token: "secret"},
+		},
+		Contexts: map[string]*api.Context{
+			"ctx-b": {Cluster: "unreachable", AuthInfo: "user"},
+			"ctx-a": {Cluster: "unreachable", AuthInfo: "user"},
+		},
+	}
+
+	err := o.runContexts(context.Background(), []string{"ctx-b", "ctx-a"})
+	require.ErrorIs(t, err, errNoReachableContexts)
+	assert.Empty(t, out.String())
+
+	warnings := errOut.String()
+	assert.Contains(t, warnings, `Warning: skipping context "ctx-a"`)
+	assert.Contains(t, warnings, `Warning: skipping context "ctx-b"`)
+	assert.Less(t, bytes.Index(errOut.Bytes(), []byte("ctx-b")), bytes.Index(errOut.Bytes(), []byte("ctx-a")))
+}
+
+func TestRunConcurrently_Limit(t *testing.T) {
+	for _, limit := range []int{1, 3, 8} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			var mu sync.Mutex
+			running, peak := 0, 0
+			calls := make([]bool, 20)
+			// the barrier opens once limit calls run at the same time, so the limit is reached but never exceeded
+			barrier := make(chan struct{})
+			var open sync.Once
+
+			runConcurrently(len(calls), limit, func(i int) {
+				mu.Lock()
+				running++
+				peak = max(peak, running)
+				if running == limit {
+					open.Do(func() { close(barrier) })
+				}
+				mu.Unlock()
+
+				select {
+				case <-barrier:
+				case <-time.After(5 * time.Second):
+					t.Error("timed out waiting for concurrent calls to reach the limit")
+				}
+
+				mu.Lock()
+				running--
+				calls[i] = true
+				mu.Unlock()
+			})
+
+			assert.Equal(t, limit, peak, "concurrency must reach but never exceed the limit")
+			assert.NotContains(t, calls, false, "every index must be run")
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/alikhil/kubectl-find/pkg/filterspec"
+	"github.com/spf13/pflag"
+)
+
+// resolveRunQuery points the filter file to the query saved under the name given by --run.
+func (o *FindOptions) resolveRunQuery() error {
+	if o.filterFile != "" {
+		return errors.New("cannot specify both --run and --filter-file flags")
+	}
+	path, err := filterspec.QueryPath(o.runQuery)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no saved query %q, save one with --save %s", o.runQuery, o.runQuery)
+	}
+	o.filterFile = path
+	return nil
+}
+
+// applyFilterFile loads the filters from --filter-file into the options.
+// Flags given on the command line win over the values from the file.
+func (o *FindOptions) applyFilterFile(flags *pflag.FlagSet) error {
+	spec, err := filterspec.Load(o.filterFile)
+	if err != nil {
+		return err
+	}
+
+	unset := func(name string) bool { return !flags.Changed(name) }
+	setString := func(name string, dst *string, value string) {
+		if value != "" && unset(name) {
+			*dst = value
+		}
+	}
+	setStrings := func(name string, dst *[]string, value []string) {
+		if len(value) > 0 && unset(name) {
+			*dst = value
+		}
+	}
+
+	if len(o.args) == 0 && spec.Type != "" {
+		o.searchType = spec.Type
+	}
+	setString("name", &o.regex, spec.Name)
+	setStrings("name-exclude", &o.nameExclude, spec.NameExclude)
+	setString("status", &o.podStatus, spec.Status)
+	setString("min-age", &o.minAge, spec.MinAge)
+	setString("max-age", &o.maxAge, spec.MaxAge)
+	setString("selector", &o.labelSelector, spec.Selector)
+	setString("field-selector", &o.fieldSelector, spec.FieldSelector)
+	setString("node", &o.nodeNameRegex, spec.Node)
+	setString("image", &o.imageRegex, spec.Image)
+	setString("container-name", &o.containerName, spec.ContainerName)
+	setStrings("env", &o.envVars, spec.Env)
+	setStrings("node-condition", &o.nodeConditions, spec.NodeConditions)
+	setStrings("condition", &o.conditions, spec.Conditions)
+	setStrings("condition-age", &o.conditionAges, spec.ConditionAges)
+	setStrings("label-compare", &o.labelCompare, spec.LabelCompare)
+	setString("jq", &o.jqFilter, spec.JQ)
+	if spec.Restarted && unset("restarted") {
+		o.restarted = true
+	}
+	if spec.MinRestarts != 0 && unset("min-restarts") {
+		o.minRestarts = spec.MinRestarts
+	}
+	setString("restart-reason", &o.restartReason, spec.RestartReason)
+
+	for _, name := range slices.Sorted(maps.Keys(spec.Flags)) {
+		if !slices.Contains(filterFlags(), name) {
+			return fmt.Errorf("invalid filter file: %q is not a filter flag", name)
+		}
+		// the namespace scope of the command line wins as a whole, e.g. -A over a saved -n
+		scope := name == "namespace" || name == "all-namespaces"
+		if !unset(name) || scope && !(unset("namespace") && unset("all-namespaces")) {
+			continue
+		}
+		if err := flags.Set(name, spec.Flags[name]); err != nil {
+			return fmt.Errorf("invalid filter file: %w", err)
+		}
+	}
+	return nil
+}
+
+// filterFlags returns the names of the filter flags without a field of their own in filter files.
+func filterFlags() []string {
+	return []string{
+		"namespace", "all-namespaces", "list-options", "selector-from", "older-than", "newer-than", "for-service",
+		"on-node", "pod-ip", "pod-ip-cidr", "host-ip", "reschedulable", "standalone", "controlled",
+		"volume-type", "volume-name", "uses-secret", "using-pvc", "uses-configmap", "requires-node-label",
+		"exit-code", "exit-code-nonzero", "image-registry", "image-tag", "latest", "by-digest", "no-digest",
+		"health", "zero-replicas", "completed", "failed-job", "stale",
+	}
+}
+
+// filterSpec returns the current filters, so they can be saved with --save.
+// Filter flags without a field of their own are taken from the flags set on the command line or by the filter file.
+func (o *FindOptions) filterSpec() *filterspec.FilterSpec {
+	spec := &filterspec.FilterSpec{
+		Type:           o.searchType,
+		Name:           o.regex,
+		NameExclude:    o.nameExclude,
+		Status:         o.podStatus,
+		MinAge:         o.minAge,
+		MaxAge:         o.maxAge,
+		Selector:       o.labelSelector,
+		FieldSelector:  o.fieldSelector,
+		Node:           o.nodeNameRegex,
+		Image:          o.imageRegex,
+		ContainerName:  o.containerName,
+		Env:            o.envVars,
+		NodeConditions: o.nodeConditions,
+		Conditions:     o.conditions,
+		ConditionAges:  o.conditionAges,
+		LabelCompare:   o.labelCompare,
+		Restarted:      o.restarted,
+		MinRestarts:    o.minRestarts,
+		RestartReason:  o.restartReason,
+		JQ:             o.jqFilter,
+	}
+	if o.flags == nil {
+		return spec
+	}
+	for _, name := range filterFlags() {
+		if flag := o.flags.Lookup(name); flag != nil && flag.Changed {
+			if spec.Flags == nil {
+				spec.Flags = map[string]string{}
+			}
+			spec.Flags[name] = flag.Value.String()
+		}
+	}
+	return spec
+}
+
+// saveQuery saves the current filters under the name given by --save, so they can be reused with --run.
+func (o *FindOptions) saveQuery() error {
+	path, err := filterspec.QueryPath(o.saveName)
+	if err != nil {
+		return err
+	}
+	if err := o.filterSpec().Write(path); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.ErrOut, "query %q saved to %s\n", o.saveName, path)
+	return err
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+func TestApplyFilterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+name: ^web-
+status: Running
+minAge: 1h
+restarted: true
+nameExclude:
+  - canary$
+`), 0o600))
+
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("find", pflag.ContinueOnError)
+	flags.StringVarP(&o.regex, "name", "r", "", "")
+	flags.StringArrayVar(&o.nameExclude, "name-exclude", nil, "")
+	flags.StringVar(&o.podStatus, "status", "", "")
+	flags.StringVar(&o.minAge, "min-age", "", "")
+	flags.BoolVar(&o.restarted, "restarted", false, "")
+	flags.StringVar(&o.filterFile, "filter-file", "", "")
+	require.NoError(t, flags.Parse([]string{"--filter-file", path, "--min-age", "24h", "--restarted=false"}))
+
+	require.NoError(t, o.applyFilterFile(flags))
+	assert.Equal(t, "^web-", o.regex)
+	assert.Equal(t, []string{"canary$"}, o.nameExclude)
+	assert.Equal(t, "Running", o.podStatus)
+	assert.Equal(t, "24h", o.minAge, "command line flags win over the filter file")
+	assert.False(t, o.restarted, "command line flags win over the filter file")
+}
+
+func TestApplyFilterFile_UnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("labels: app=web\n"), 0o600))
+
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.filterFile = path
+	err := o.applyFilterFile(pflag.NewFlagSet("find", pflag.ContinueOnError))
+	require.ErrorContains(t, err, `unknown field "labels"`)
+}
+
+func TestSaveAndRunQuery(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	saved := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	saved.searchType = "jobs"
+	saved.saveName = "old-jobs"
+	saved.regex = "^backup-"
+	saved.minAge = "168h"
+	saved.restarted = true
+	saved.flags = pflag.NewFlagSet("find", pflag.ContinueOnError)
+	saved.flags.StringP("namespace", "n", "", "")
+	saved.flags.BoolVar(&saved.failedJob, "failed-job", false, "")
+	saved.flags.BoolVar(&saved.delete, "delete", false, "")
+	require.NoError(t, saved.flags.Parse([]string{"-n", "backups", "--failed-job", "--delete"}))
+	require.NoError(t, saved.saveQuery())
+
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("find", pflag.ContinueOnError)
+	flags.StringVar(&o.minAge, "min-age", "", "")
+	flags.StringVar(&o.runQuery, "run", "", "")
+	flags.StringP("namespace", "n", "", "")
+	flags.BoolVar(&o.failedJob, "failed-job", false, "")
+	flags.BoolVar(&o.delete, "delete", false, "")
+	require.NoError(t, flags.Parse([]string{"--run", "old-jobs", "--min-age", "24h"}))
+
+	require.NoError(t, o.resolveRunQuery())
+	require.NoError(t, o.applyFilterFile(flags))
+	assert.Equal(t, "jobs", o.searchType)
+	assert.Equal(t, "^backup-", o.regex)
+	assert.Equal(t, "24h", o.minAge, "command line flags win over the saved query")
+	assert.True(t, o.restarted)
+	assert.True(t, o.failedJob, "filter flags without a field of their own are saved too")
+	namespace, err := flags.GetString("namespace")
+	require.NoError(t, err)
+	assert.Equal(t, "backups", namespace)
+	assert.False(t, o.delete, "actions are not saved")
+
+	o = NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags = pflag.NewFlagSet("find", pflag.ContinueOnError)
+	flags.StringVar(&o.runQuery, "run", "", "")
+	flags.StringP("namespace", "n", "", "")
+	flags.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "")
+	flags.BoolVar(&o.failedJob, "failed-job", false, "")
+	require.NoError(t, flags.Parse([]string{"--run", "old-jobs", "-A"}))
+
+	require.NoError(t, o.resolveRunQuery())
+	require.NoError(t, o.applyFilterFile(flags))
+	namespace, err = flags.GetString("namespace")
+	require.NoError(t, err)
+	assert.Empty(t, namespace, "--all-namespaces on the command line wins over the saved namespace")
+}
+
+func TestApplyFilterFile_Flags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("flags:\n  for-service: web\n  uses-secret: tls\n"), 0o600))
+
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("find", pflag.ContinueOnError)
+	flags.StringVar(&o.forService, "for-service", "", "")
+	flags.StringVar(&o.usesSecret, "uses-secret", "", "")
+	flags.StringVar(&o.filterFile, "filter-file", "", "")
+	require.NoError(t, flags.Parse([]string{"--filter-file", path, "--uses-secret", "db"}))
+
+	require.NoError(t, o.applyFilterFile(flags))
+	assert.Equal(t, "web", o.forService)
+	assert.Equal(t, "db", o.usesSecret, "command line flags win over the filter file")
+
+	require.NoError(t, os.WriteFile(path, []byte("flags:\n  delete: \"true\"\n"), 0o600))
+	require.EqualError(t, o.applyFilterFile(flags), `invalid filter file: "delete" is not a filter flag`)
+}
+
+func TestResolveRunQuery_Errors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	o := NewFindOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.runQuery = "missing"
+	require.EqualError(t, o.resolveRunQuery(), `no saved query "missing", save one with --save missing`)
+
+	o.filterFile = "filters.yaml"
+	require.EqualError(t, o.resolveRunQuery(), "cannot specify both --run and --filter-file flags")
+}
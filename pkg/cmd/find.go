@@ -20,17 +20,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/itchyny/gojq"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/alikhil/kubectl-find/pkg"
 	"github.com/alikhil/kubectl-find/pkg/handlers"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
@@ -39,6 +50,7 @@ import (
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
@@ -56,8 +68,14 @@ var (
 	# find all externalecrets and patch them to force sync
 	%[1]s find externalsecret -A --patch '{"metadata": {"annotations": {"force-sync": "'$(date)'"}}}'
 
+	# label every deployment with its own name
+	%[1]s find deployments --patch-template '{"metadata": {"labels": {"app": "{{ .metadata.name }}"}}}'
+
 	# find all failed pods and delete them
 	%[1]s find pods --status failed -delete -A
+
+	# find resources of a type served by several API groups, qualified as resource.group or resource.version.group
+	%[1]s find certificates.cert-manager.io -A
 `
 
 	errNoContext = fmt.Errorf(
@@ -66,45 +84,160 @@ var (
 	)
 )
 
+const (
+	outputWide      = "wide"
+	outputName      = "name"
+	outputJSONLines = "jsonl"
+
+	outputTemplateColumns = "template-columns="
+)
+
+const defaultEvictTimeout = 5 * time.Minute
+
 // FindOptions provides information required to handle the `find` command.
 type FindOptions struct {
 	configFlags *genericclioptions.ConfigFlags
 
 	userSpecifiedNamespace string
-
-	rawConfig api.Config
-	rest      *rest.Config
-
-	allNamespaces bool
-	searchType    string
-	delete        bool
-	exec          string
-	patch         string
-	annotate      string
-	regex         string
-	podStatus     string
-	minAge        string
-	maxAge        string
-	labelSelector string
-	nodeNameRegex string
-	skipConfirm   bool
-	force         bool
-	restarted     bool
-	imageRegex    string
-	jqFilter      string
-	naturalSort   bool
+	namespaceSpecified     bool
+
+	rawConfig      api.Config
+	currentContext string
+	rest           *rest.Config
+
+	allNamespaces   bool
+	allContexts     bool
+	contexts        []string
+	maxConcurrent   int
+	ignoreForbidden bool
+	forbiddenSet    bool // --ignore-forbidden was given explicitly, otherwise it is on only for listing
+	searchType      string
+	delete          bool
+	exec            string
+	execEach        string
+	dryRun          bool
+	count           bool
+	includeNotReady bool
+	patch           string
+	patchTemplate   string
+	patchType       string
+	forceConflicts  bool
+	diff            bool
+	previewDiff     bool
+	saveTo          string
+	applyFrom       string
+	progressJSON    bool
+	profile         bool
+	stats           bool
+	countByNS       bool
+	auditLog        string
+	listTypes       bool
+	summary         bool
+	namespacedOnly  bool
+	apiGroup        string
+	annotate        string
+	regex           string
+	nameExclude     []string
+	podStatus       string
+	minAge          string
+	maxAge          string
+	labelSelector   string
+	fieldSelector   string
+	listOptions     string
+	hintOnEmpty     bool
+	selectorFrom    string
+	olderThan       string
+	newerThan       string
+	createdBefore   time.Time
+	createdAfter    time.Time
+	forService      string
+	nodeNameRegex   string
+	onNode          string
+	podIP           string
+	podIPCIDR       string
+	hostIP          string
+	reschedulable   bool
+	standalone      bool
+	controlled      bool
+	skipConfirm     bool
+	force           bool
+	evict           bool
+	evictTimeout    time.Duration
+	restarted       bool
+	minRestarts     int32
+	restartReason   string
+	exitCode        int32
+	exitCodeSet     bool // --exit-code was given explicitly, 0 is a valid exit code to filter by
+	exitCodeNonzero bool
+	imageRegex      string
+	containerName   string
+	imageRegistry   string
+	imageTag        string
+	latest          bool
+	byDigest        bool
+	noDigest        bool
+	jqFilter        string
+	filterFile      string
+	saveName        string
+	runQuery        string
+	naturalSort     bool
+	sortBy          string
+	reverse         bool
+	watch           bool
+	watchOnly       bool
+	health          bool
+	flattenToPods   bool
+	zeroReplicas    bool
+	completed       bool
+	failedJob       bool
+	stale           bool
+	serverColumns   bool
+	showNamespace   bool
+	noNamespace     bool
+	customColumns   string
+	showOwner       bool
+	showConditions  bool
+	tree            bool
+	listImages      bool
+	restartTimeline bool
+	colocated       bool
+	output          string
+	raw             bool
+	canonicalOrder  bool
+	alsoJSON        string
+	alsoJSONFile    *jsonFile
 
 	nodeConditions []string
+	conditions     []string
+	conditionAges  []string
+	labelCompare   []string
+	envVars        []string
+	volumeType     string
+	volumeName     string
+	usesSecret     string
+	usesPVC        string
+	usesConfigMap  string
+	requiresLabel  string
 
 	showNodeLabels  []string
 	showLabels      []string
 	showAnnotations []string
-
-	args []string
-
-	resourceType handlers.Resource
-	handler      handlers.ResourceHandler
-	options      handlers.ActionOptions
+	selectColumns   []string
+	hideColumns     []string
+
+	args  []string
+	flags *pflag.FlagSet // flags of the command, set by Complete
+
+	resourceType   handlers.Resource
+	handlerOptions handlers.HandlerOptions
+	targetContexts []string // kubeconfig contexts to search in, set by --contexts or --all-contexts
+	applyOptions   handlers.ApplyFromOptions
+	profiler       *handlers.Profiler // records phase timings when --profile is set
+	typesFilter    resourceTypesFilter
+	typesClient    discovery.DiscoveryInterface
+	summaryClient  dynamic.Interface // counts objects of every resource type with --summary
+	handler        handlers.ResourceHandler
+	options        handlers.ActionOptions
 
 	genericiooptions.IOStreams
 }
@@ -114,8 +247,9 @@ func NewFindOptions(streams genericiooptions.IOStreams) *FindOptions {
 	return &FindOptions{
 		configFlags: genericclioptions.NewConfigFlags(true),
 
-		IOStreams:  streams,
-		searchType: "pods",
+		IOStreams:     streams,
+		searchType:    "pods",
+		maxConcurrent: defaultMaxConcurrent,
 	}
 }
 
@@ -150,13 +284,69 @@ func NewCmdFind(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().
 		StringVarP(&o.regex, "name", "r", "", "Regular expression to match resource names against; if not specified, all resources of the specified type will be returned.")
 	cmd.Flags().
-		StringVar(&o.podStatus, "status", "", "Filter pods by their status (phase); e.g. 'Running', 'Pending', 'Succeeded', 'Failed', 'Unknown'.")
+		StringArrayVar(&o.nameExclude, "name-exclude", nil,
+			"Regular expression to exclude resources whose names match; applied after --name. Can be repeated.")
+	cmd.Flags().
+		StringVar(&o.podStatus, "status", "",
+			"Filter resources by status.phase; e.g. 'Running', 'Pending', 'Succeeded', 'Failed', 'Unknown' for pods, 'Terminating' for namespaces, 'Bound' for PVCs.")
 	cmd.Flags().
 		BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "Search in all namespaces; if not specified, only the current namespace will be searched.")
+	cmd.Flags().
+		BoolVar(&o.allContexts, "all-contexts", false, "Search in all contexts from kubeconfig; output rows are prefixed with a CONTEXT column.")
+	cmd.Flags().
+		StringSliceVar(&o.contexts, "contexts", nil,
+			"Comma-separated list of kubeconfig contexts to search in; output rows are prefixed with a CONTEXT column when more than one is given.")
+	cmd.Flags().
+		BoolVar(&o.ignoreForbidden, "ignore-forbidden", false,
+			"With --all-namespaces, skip namespaces you are not allowed to list in when listing all of them is forbidden; "+
+				"on by default when listing, off for actions.")
+	cmd.Flags().
+		IntVar(&o.maxConcurrent, "max-concurrent", defaultMaxConcurrent,
+			"Maximum number of contexts searched at the same time with --contexts or --all-contexts, or of types counted with --summary.")
 	cmd.Flags().StringVarP(&o.labelSelector, "selector", "l", "", "Label selector to filter resources by labels.")
+	cmd.Flags().
+		StringVar(&o.fieldSelector, "field-selector", "",
+			"Field selector passed to the API server (e.g. status.phase=Running), combined with --selector and --on-node.")
+	cmd.Flags().
+		StringVar(&o.listOptions, "list-options", "",
+			"Raw ListOptions JSON merged into every list call (e.g. '{\"limit\": 100, \"timeoutSeconds\": 30}'); --selector and --field-selector win.")
+	cmd.Flags().
+		BoolVar(&o.hintOnEmpty, "hint-on-empty", false,
+			"When nothing matches --selector, print the label keys of the resources in scope to stderr to help spot typos.")
+	cmd.Flags().
+		StringVar(&o.selectorFrom, "selector-from", "",
+			"Use all labels of the given TYPE/NAME object (e.g. pod/web-1) as the label selector to find its siblings.")
+	cmd.Flags().
+		StringVar(&o.olderThan, "older-than", "",
+			"Find resources created before the given TYPE/NAME object (e.g. deployment/web).")
+	cmd.Flags().
+		StringVar(&o.newerThan, "newer-than", "",
+			"Find resources created after the given TYPE/NAME object (e.g. deployment/web).")
+	cmd.Flags().
+		StringVar(&o.forService, "for-service", "", "Find pods targeted by the selector of the service.")
 	cmd.Flags().BoolVar(&o.delete, "delete", false, "Delete all matched resources.")
 	cmd.Flags().StringVarP(&o.exec, "exec", "e", "", "Execute a command on all found pods.")
+	cmd.Flags().StringVar(&o.execEach, "exec-each", "",
+		"Run a local command for every found resource, without a shell; every argument is a Go template executed against the resource (e.g. 'echo {{ .metadata.name }}').")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Print the pods --exec would run the command on without executing it.")
+	cmd.Flags().BoolVar(&o.count, "count", false, "Print the number of pods --exec would run the command on without executing it.")
+	cmd.Flags().BoolVar(&o.includeNotReady, "include-not-ready", false,
+		"Also run the --exec command on pods that are not ready, e.g. starting or terminating.")
 	cmd.Flags().StringVarP(&o.patch, "patch", "p", "", "Patch all found resources with the specified JSON patch.")
+	cmd.Flags().StringVar(&o.patchTemplate, "patch-template", "",
+		"Patch every found resource with the output of the Go template executed against the resource (e.g. '{{ .metadata.name }}').")
+	cmd.Flags().StringVar(&o.patchType, "patch-type", "",
+		"Type of --patch: 'strategic' (default), 'merge', 'json' or 'apply' for server-side apply.")
+	cmd.Flags().BoolVar(&o.forceConflicts, "force-conflicts", false,
+		"Take ownership of fields managed by other field managers when patching with --patch-type=apply.")
+	cmd.Flags().BoolVar(&o.diff, "diff", false, "Show a diff of the changes --patch would make before applying it.")
+	cmd.Flags().
+		BoolVar(&o.previewDiff, "preview-diff", false,
+			"Show diffs of the first few resources --patch would change in the confirmation prompt.")
+	cmd.Flags().StringVar(&o.saveTo, "save-to", "",
+		"Save matched resources as cleaned YAML files named namespace_kind_name.yaml into the directory before performing the action.")
+	cmd.Flags().StringVar(&o.applyFrom, "apply-from", "",
+		"Re-create resources from manifests in the directory (e.g. saved with --save-to) using server-side apply.")
 	cmd.Flags().StringVar(&o.annotate, "annotate", "",
 		"Annotate all found resources; format: k=v[,k2=v2] to add/overwrite or k- to remove annotations.")
 	cmd.Flags().
@@ -167,14 +357,93 @@ func NewCmdFind(streams genericiooptions.IOStreams) *cobra.Command {
 		BoolVarP(&o.skipConfirm, "skip-confirm", "y", false, "Skip confirmation prompt before performing actions on resources.")
 	cmd.Flags().
 		BoolVar(&o.force, "force", false, "If true, immediately remove resources from API and bypass graceful deletion. Can only be used with --delete flag.")
+	cmd.Flags().
+		BoolVar(&o.evict, "evict", false,
+			"Delete pods with the eviction API, so pod disruption budgets are respected. Can only be used with --delete flag.")
+	cmd.Flags().
+		DurationVar(&o.evictTimeout, "evict-timeout", defaultEvictTimeout,
+			"How long to retry an eviction blocked by a pod disruption budget before giving up.")
 	cmd.Flags().
 		StringVar(&o.nodeNameRegex, "node", "", "Filter pods by node name regex; Uses pod.Spec.NodeName or pod.Status.NominatedNodeName if the former is empty.")
+	cmd.Flags().
+		StringVar(&o.onNode, "on-node", "",
+			"Filter pods scheduled to the node with exactly this name (pod.Spec.NodeName); the filter is applied by the API server.")
+	cmd.Flags().
+		StringVar(&o.podIP, "pod-ip", "", "Filter pods with this IP address, including secondary IPs of dual-stack pods.")
+	cmd.Flags().
+		StringVar(&o.podIPCIDR, "pod-ip-cidr", "",
+			"Filter pods with an IP address in the CIDR range (e.g. '10.1.0.0/16'), including secondary IPs of dual-stack pods.")
+	cmd.Flags().
+		StringVar(&o.hostIP, "host-ip", "", "Filter pods running on the node with this IP address (pod.Status.HostIP).")
+	cmd.Flags().
+		BoolVar(&o.reschedulable, "reschedulable", false,
+			"Find pods a node drain would move, skipping DaemonSet and mirror pods; with --delete the pods are evicted.")
+	cmd.Flags().
+		BoolVar(&o.standalone, "standalone", false,
+			"Find pods without a controller owner reference, e.g. created manually, which are not recreated once deleted.")
+	cmd.Flags().
+		BoolVar(&o.controlled, "controlled", false, "Find pods with a controller owner reference, e.g. of a ReplicaSet or a Job.")
+	cmd.Flags().
+		StringArrayVar(&o.envVars, "env", nil,
+			"Filter pods by environment variables declared in any container; format: KEY=VALUE or KEY to match any value; can be repeated. "+
+				"Variables set with valueFrom are matched by name only.")
+	cmd.Flags().
+		StringVar(&o.volumeType, "volume-type", "",
+			"Filter pods having a volume of the type: 'hostPath', 'secret', 'configMap' or 'pvc'.")
+	cmd.Flags().
+		StringVar(&o.volumeName, "volume-name", "",
+			"Filter pods having a volume with the name, or using the secret, config map, claim or host path of the name.")
+	cmd.Flags().
+		StringVar(&o.usesSecret, "uses-secret", "",
+			"Filter pods using the secret in a volume, envFrom, env valueFrom or as an image pull secret.")
+	cmd.Flags().
+		StringVar(&o.usesPVC, "using-pvc", "", "Filter pods mounting the persistent volume claim, e.g. before deleting it.")
+	cmd.Flags().
+		StringVar(&o.usesConfigMap, "uses-configmap", "",
+			"Filter pods using the config map in a volume, envFrom or env valueFrom, e.g. before editing it.")
+	cmd.Flags().
+		StringVar(&o.requiresLabel, "requires-node-label", "",
+			"Filter pods whose nodeSelector or required node affinity demands the node label; format: KEY=VALUE.")
 	cmd.Flags().
 		BoolVar(&o.restarted, "restarted", false, "Find pods that have been restarted at least once.")
+	cmd.Flags().
+		Int32Var(&o.minRestarts, "min-restarts", 0, "Find pods whose containers have restarted at least N times in total.")
+	cmd.Flags().
+		StringVar(&o.restartReason, "restart-reason", "",
+			"Find pods with a container whose current or last termination has the reason (e.g. 'OOMKilled', 'Error').")
+	cmd.Flags().
+		Int32Var(&o.exitCode, "exit-code", 0,
+			"Find pods with a container whose current or last termination exited with the code (e.g. 137 for OOM kills).")
+	cmd.Flags().
+		BoolVar(&o.exitCodeNonzero, "exit-code-nonzero", false,
+			"Find pods with a container whose current or last termination exited with a nonzero code.")
 	cmd.Flags().
 		StringVar(&o.imageRegex, "image", "", "Regular expression to match container images against.")
+	cmd.Flags().
+		StringVar(&o.containerName, "container-name", "",
+			"Regular expression to match names of containers and init containers against (e.g. 'istio-proxy').")
+	cmd.Flags().
+		StringVar(&o.imageRegistry, "image-registry", "",
+			"Filter pods having a container image from the registry host (e.g. 'ghcr.io'); images without a host are from 'docker.io'.")
+	cmd.Flags().
+		StringVar(&o.imageTag, "image-tag", "",
+			"Filter pods having a container image with the tag; images without a tag and digest have the 'latest' tag.")
+	cmd.Flags().
+		BoolVar(&o.latest, "latest", false, "Find pods having a container image with the 'latest' tag; same as --image-tag=latest.")
+	cmd.Flags().
+		BoolVar(&o.byDigest, "by-digest", false, "Find pods whose container images are all referenced by digest (e.g. 'nginx@sha256:...').")
+	cmd.Flags().
+		BoolVar(&o.noDigest, "no-digest", false, "Find pods having a container image referenced only by a mutable tag, without digest.")
 	cmd.Flags().
 		StringVarP(&o.jqFilter, "jq", "j", "", "jq expression to filter resources; Uses gojq library for evaluation.")
+	cmd.Flags().
+		StringVar(&o.filterFile, "filter-file", "",
+			"Load filters from a YAML file (e.g. name, status, minAge, selector, jq); flags given on the command line win.")
+	cmd.Flags().
+		StringVar(&o.saveName, "save", "",
+			"Save the resource type and filters as a named query to ~/.config/kubectl-find/queries before running it.")
+	cmd.Flags().
+		StringVar(&o.runQuery, "run", "", "Run the named query saved with --save; flags given on the command line win.")
 	cmd.Flags().
 		StringSliceVarP(&o.showNodeLabels, "node-labels", "N", nil, "Comma-separated list of node labels to show.")
 	cmd.Flags().
@@ -183,11 +452,116 @@ func NewCmdFind(streams genericiooptions.IOStreams) *cobra.Command {
 		StringSliceVarP(&o.showAnnotations, "annotations", "T", nil, "Comma-separated list of annotations to show.")
 	cmd.Flags().
 		BoolVar(&o.naturalSort, "natural-sort", false, "Sort resource names in natural order.")
+	cmd.Flags().
+		StringVar(&o.sortBy, "sort", "",
+			"Sort matched resources; 'age' sorts by creation timestamp, oldest first, "+
+				"for pods 'restarts' sorts by restart count, most restarted first, and 'status' by phase.")
+	cmd.Flags().
+		BoolVarP(&o.watch, "watch", "w", false,
+			"After listing matched resources, watch for changes and print resources that match, marked with the event type.")
+	cmd.Flags().
+		BoolVar(&o.watchOnly, "watch-only", false,
+			"Watch for changes and print resources that match, without listing the current ones first.")
+	cmd.Flags().
+		BoolVar(&o.reverse, "reverse", false, "Reverse the order of --sort or --natural-sort, e.g. newest first with --sort=age.")
 	cmd.Flags().
 		StringSliceVar(&o.nodeConditions, "node-condition", nil,
 			"Filter nodes by conditions; format: ConditionType=Status (e.g. 'Ready=True', 'DiskPressure=False'). Supports custom conditions from NPD or other agents.")
+	cmd.Flags().
+		StringSliceVar(&o.conditions, "condition", nil,
+			"Filter resources by status conditions; format: ConditionType=Status (e.g. 'Available=False'), Status '*' matches any status.")
+	cmd.Flags().
+		StringSliceVar(&o.conditionAges, "condition-age", nil,
+			"Filter resources whose condition has had a status for at least a duration, by its lastTransitionTime; format: ConditionType=Status:Duration (e.g. 'Ready=False:10m').")
+	cmd.Flags().
+		StringArrayVar(&o.labelCompare, "label-compare", nil,
+			"Filter resources by comparing a label value as a number with ==, !=, >, >=, < or <= (e.g. 'version>3'); can be repeated.")
+	cmd.Flags().
+		BoolVar(&o.health, "health", false,
+			"Find deployments, statefulsets or daemonsets that are not fully available, show their health status and how many are unhealthy.")
+	cmd.Flags().
+		BoolVar(&o.zeroReplicas, "zero-replicas", false,
+			"Find deployments, statefulsets or replicasets scaled to zero; replicasets that are the current revision of their deployment are skipped.")
+	cmd.Flags().
+		BoolVar(&o.flattenToPods, "flatten-to-pods", false,
+			"Print the pods of matched deployments, statefulsets or daemonsets, selected by their spec.selector, instead of the workloads.")
+	cmd.Flags().
+		BoolVar(&o.completed, "completed", false, "Find completed jobs, or pods that succeeded; same as --status=Succeeded for pods.")
+	cmd.Flags().
+		BoolVar(&o.failedJob, "failed-job", false, "Find jobs that have failed, e.g. reached their backoff limit or deadline.")
+	cmd.Flags().
+		BoolVar(&o.stale, "stale", false,
+			"Find resources whose controller has not observed the latest generation (metadata.generation != status.observedGeneration).")
+	cmd.Flags().
+		BoolVar(&o.serverColumns, "server-columns", false,
+			"Print columns defined by the API server (as in 'kubectl get'), including CRD printer columns.")
+	cmd.Flags().
+		BoolVar(&o.showNamespace, "show-namespace", false, "Always show the NAMESPACE column, even without --all-namespaces.")
+	cmd.Flags().
+		BoolVar(&o.noNamespace, "no-namespace", false, "Never show the NAMESPACE column, even with --all-namespaces.")
+	cmd.Flags().
+		StringVar(&o.customColumns, "custom-columns", "",
+			"Print only the given columns; format: HEADER:JSONPATH[,HEADER2:JSONPATH2] (e.g. 'NAME:.metadata.name,NODE:.spec.nodeName'); append :age to print a timestamp as age.")
+	cmd.Flags().
+		StringVarP(&o.output, "output", "o", "",
+			"Output format; 'wide' shows additional columns, 'name' prints only resource/name, 'jsonl' prints each object as a JSON line, "+
+				"'template-columns=HEADER=EXPR,...' prints columns of JSONPath expressions and age(), json() and default() functions.")
+	cmd.Flags().
+		BoolVar(&o.raw, "raw", false,
+			"Print the full matched object, including status and managed fields, as JSON; fails if more than one resource matches.")
+	cmd.Flags().
+		BoolVar(&o.canonicalOrder, "canonical-order", false,
+			"Print apiVersion, kind, metadata, spec and status first in --raw and --output=jsonl objects, like 'kubectl get -o json'.")
+	cmd.Flags().
+		StringVar(&o.alsoJSON, "also-json", "",
+			"Write matched objects as JSON lines to the file too, in addition to the output printed to stdout.")
+	cmd.Flags().
+		StringSliceVar(&o.selectColumns, "columns", nil,
+			"Comma-separated list of column headers to show, in order (e.g. 'NAME,STATUS'); case-insensitive.")
+	cmd.Flags().
+		StringSliceVar(&o.hideColumns, "hide-columns", nil,
+			"Comma-separated list of column headers to drop from the default columns (e.g. 'READY,RESTARTS'); case-insensitive, unknown headers are ignored.")
+	cmd.Flags().
+		BoolVar(&o.showOwner, "show-owner", false, "Show the controller owner of each resource as Kind/name.")
+	cmd.Flags().
+		BoolVar(&o.showConditions, "show-conditions", false, "Show status conditions of each resource as Type=Status.")
+	cmd.Flags().
+		BoolVar(&o.tree, "tree", false, "Print matched resources with their owners as a tree (e.g. Deployment -> ReplicaSet -> Pod).")
+	cmd.Flags().
+		BoolVar(&o.listImages, "list-images", false, "Print distinct container images of matched pods with the number of pods using each.")
+	cmd.Flags().
+		BoolVar(&o.restartTimeline, "restart-timeline", false,
+			"Print when and why the containers of the single matched pod terminated, with reasons and exit codes.")
+	cmd.Flags().
+		BoolVar(&o.colocated, "colocated-on-node", false,
+			"Print nodes hosting more than one matched pod of the same controller, e.g. to find replicas that are not spread.")
+	cmd.Flags().
+		BoolVar(&o.progressJSON, "progress-json", false, "Emit machine-readable progress events as JSON lines on stderr.")
+	cmd.Flags().
+		BoolVar(&o.profile, "profile", false,
+			"Print how long discovery, listing and the action took and how many objects were processed to stderr.")
+	cmd.Flags().
+		BoolVar(&o.stats, "stats", false, "Print how many objects were fetched and how many of them matched to stderr.")
+	cmd.Flags().
+		BoolVar(&o.countByNS, "count-by-namespace", false,
+			"Print how many objects matched in each namespace to stderr, e.g. 'default: 12, kube-system: 30'.")
+	cmd.Flags().
+		StringVar(&o.auditLog, "audit-log", "",
+			"Write a JSON record of the run to the file: context, user, filters, action and matched resources with the outcome for each.")
+	cmd.Flags().
+		BoolVar(&o.listTypes, "list-resource-types", false,
+			"List resource types that can be searched, with their short names, API version, scope and kind.")
+	cmd.Flags().
+		BoolVar(&o.namespacedOnly, "namespaced-only", false, "List only namespaced resource types; used with --list-resource-types.")
+	cmd.Flags().
+		StringVar(&o.apiGroup, "api-group", "",
+			"List only resource types in the API group, use '' for the core group; used with --list-resource-types.")
+	cmd.Flags().
+		BoolVar(&o.summary, "summary", false,
+			"Print how many objects of every namespaced resource type are in the namespace, skipping types you cannot list.")
 
 	o.configFlags.AddFlags(cmd.Flags())
+	addVerbosityFlag(cmd.PersistentFlags())
 
 	return cmd
 }
@@ -195,15 +569,28 @@ func NewCmdFind(streams genericiooptions.IOStreams) *cobra.Command {
 // Complete sets all information required for updating the current context.
 func (o *FindOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.args = args
+	o.flags = cmd.Flags()
 
 	if len(o.args) > 0 {
 		o.searchType = o.args[0]
 	}
 
+	if o.runQuery != "" {
+		if err := o.resolveRunQuery(); err != nil {
+			return err
+		}
+	}
+	if o.filterFile != "" {
+		if err := o.applyFilterFile(cmd.Flags()); err != nil {
+			return err
+		}
+	}
 	var err error
+	// with --contexts or --all-contexts the current context is not used, so it may be missing or broken
+	multiContext := o.allContexts || len(o.contexts) > 0
 	loader := o.configFlags.ToRawKubeConfigLoader()
 	o.rest, err = loader.ClientConfig()
-	if err != nil {
+	if err != nil && !multiContext {
 		return fmt.Errorf("unable to create REST config: %w", err)
 	}
 
@@ -212,8 +599,14 @@ func (o *FindOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to retrieve raw kubeconfig: %w", err)
 	}
 
-	currentContext, exists := o.rawConfig.Contexts[o.rawConfig.CurrentContext]
-	if !exists {
+	// raw config merges all files from KUBECONFIG but does not apply overrides like --context
+	o.currentContext = o.rawConfig.CurrentContext
+	if o.configFlags.Context != nil && *o.configFlags.Context != "" {
+		o.currentContext = *o.configFlags.Context
+	}
+
+	currentContext, exists := o.rawConfig.Contexts[o.currentContext]
+	if !exists && !multiContext {
 		return errNoContext
 	}
 
@@ -225,13 +618,21 @@ func (o *FindOptions) Complete(cmd *cobra.Command, args []string) error {
 	if o.userSpecifiedNamespace != "" && o.allNamespaces {
 		return errors.New("cannot specify both --namespace and --all-namespaces flags")
 	}
+	o.namespaceSpecified = o.userSpecifiedNamespace != ""
+	o.forbiddenSet = cmd.Flags().Changed("ignore-forbidden")
+	o.exitCodeSet = cmd.Flags().Changed("exit-code")
+	if cmd.Flags().Changed("api-group") {
+		o.typesFilter.apiGroup = &o.apiGroup
+	}
 
 	// if no namespace argument or flag value was specified, then use the current context's namespace
 	if len(o.userSpecifiedNamespace) == 0 {
 		if o.allNamespaces {
 			o.userSpecifiedNamespace = ""
 		} else {
-			o.userSpecifiedNamespace = currentContext.Namespace
+			if exists {
+				o.userSpecifiedNamespace = currentContext.Namespace
+			}
 			if len(o.userSpecifiedNamespace) == 0 {
 				o.userSpecifiedNamespace = "default" // default namespace if none is specified
 			}
@@ -241,21 +642,15 @@ func (o *FindOptions) Complete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func cleanResourceName(resource string) string {
-	if strings.Contains(resource, ".") {
-		// If the resource contains a dot, it is likely a namespaced resource like "pods.v1"
-		// We only want the resource name part, so we split by dot and take the first part.
-		return strings.Split(resource, ".")[0]
-	}
-
-	if strings.Contains(resource, "/") {
-		return strings.Split(resource, "/")[0]
-	}
-	return resource
+// parseResourceArg parses the resource type given as resource, resource.group or resource.version.group.
+// The name in the resource/name form is ignored.
+func parseResourceArg(arg string) (*schema.GroupVersionResource, schema.GroupResource) {
+	arg, _, _ = strings.Cut(strings.ToLower(arg), "/")
+	return schema.ParseResourceArg(arg)
 }
 
-func (o *FindOptions) findResource(resource string) (handlers.Resource, error) {
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.rest)
+func findResource(config *rest.Config, resource string) (handlers.Resource, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
 	empty := handlers.Resource{}
 	if err != nil {
 		return empty, fmt.Errorf("unable to create discovery client: %w", err)
@@ -268,12 +663,32 @@ func (o *FindOptions) findResource(resource string) (handlers.Resource, error) {
 		nil, // no warning handler
 	)
 
-	resource = cleanResourceName(resource)
+	return resolveResource(discoveryClient, restMapper, resource)
+}
 
-	gvr := schema.GroupVersionResource{Resource: resource}
+// resolveResource resolves the resource type given by the user to the resource served by the cluster.
+func resolveResource(
+	discoveryClient discovery.ServerResourcesInterface,
+	restMapper meta.RESTMapper,
+	resource string,
+) (handlers.Resource, error) {
+	empty := handlers.Resource{}
+	fullySpecified, groupResource := parseResourceArg(resource)
 
-	resolved, err := restMapper.ResourceFor(gvr)
+	// resource.version.group is ambiguous with resource.group when the group has dots, try both like kubectl does
+	var resolved schema.GroupVersionResource
+	var err error
+	if fullySpecified != nil {
+		resolved, err = restMapper.ResourceFor(*fullySpecified)
+	}
+	if fullySpecified == nil || err != nil {
+		resolved, err = restMapper.ResourceFor(groupResource.WithVersion(""))
+	}
 	if err != nil {
+		if candidates := ambiguousResourceCandidates(err); len(candidates) > 0 {
+			return empty, fmt.Errorf("resource type %q is ambiguous, use one of the fully qualified names: %s",
+				resource, strings.Join(candidates, ", "))
+		}
 		return empty, fmt.Errorf("unable to resolve resource %s: %w", resource, err)
 	}
 
@@ -289,13 +704,14 @@ func (o *FindOptions) findResource(resource string) (handlers.Resource, error) {
 		return empty, fmt.Errorf("unable to get server resources for group version %q: %w", groupVersion, err)
 	}
 
-	for _, resource := range apiResourceList.APIResources {
-		if resource.Name == resolved.Resource {
+	for _, apiResource := range apiResourceList.APIResources {
+		if apiResource.Name == resolved.Resource {
+			klog.V(2).InfoS("Resolved resource type", "input", resource, "resource", resolved.String(), "kind", gvk.Kind)
 			return handlers.Resource{
 				GroupVersionResource: resolved,
-				PluralName:           resource.Name,
-				SingularName:         resource.SingularName,
-				IsNamespaced:         resource.Namespaced,
+				PluralName:           apiResource.Name,
+				SingularName:         apiResource.SingularName,
+				IsNamespaced:         apiResource.Namespaced,
 				GroupVersionKind:     gvk,
 			}, nil
 		}
@@ -303,50 +719,379 @@ func (o *FindOptions) findResource(resource string) (handlers.Resource, error) {
 	return empty, fmt.Errorf("resource %q not found in group version %q", resource, groupVersion)
 }
 
-// Validate ensures that all required arguments and flag values are provided.
-func (o *FindOptions) Validate() error {
-	if len(o.rawConfig.CurrentContext) == 0 {
-		return errNoContext
+// ambiguousResourceCandidates returns the sorted <resource>.<group> names of all resources matching the user input
+// when err reports an ambiguous resource or kind, otherwise nil.
+func ambiguousResourceCandidates(err error) []string {
+	var matching []schema.GroupVersionResource
+	var resourceErr *meta.AmbiguousResourceError
+	var kindErr *meta.AmbiguousKindError
+	switch {
+	case errors.As(err, &resourceErr):
+		matching = resourceErr.MatchingResources
+	case errors.As(err, &kindErr):
+		matching = kindErr.MatchingResources
+	default:
+		return nil
 	}
 
-	var err error
-	o.resourceType, err = o.findResource(o.searchType)
-	if err != nil {
-		return fmt.Errorf("unable to find resource type %q: %w", o.searchType, err)
+	var candidates []string
+	for _, gvr := range matching {
+		name := gvr.GroupResource().String() // resource.group, or just resource for the core group
+		if !slices.Contains(candidates, name) {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+func (o *FindOptions) validateListResourceTypes() error {
+	if len(o.args) > 0 || o.delete || o.patching() || o.exec != "" || o.execEach != "" || o.annotate != "" || o.saveTo != "" {
+		return errors.New("--list-resource-types flag cannot be combined with a resource type or actions")
+	}
+	if len(o.contexts) > 0 || o.allContexts {
+		return errors.New("--list-resource-types flag cannot be combined with --contexts or --all-contexts flags")
 	}
 
-	clientSet, err := kubernetes.NewForConfig(o.rest)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.rest)
 	if err != nil {
-		return fmt.Errorf("unable to create kubernetes client: %w", err)
+		return fmt.Errorf("unable to create discovery client: %w", err)
 	}
+	o.typesClient = discoveryClient
+	o.typesFilter.namespacedOnly = o.namespacedOnly
+	return nil
+}
 
-	dynamic, err := dynamic.NewForConfig(o.rest)
+func (o *FindOptions) validateSummary() error {
+	if len(o.args) > 0 || o.delete || o.patching() || o.exec != "" || o.execEach != "" || o.annotate != "" || o.saveTo != "" {
+		return errors.New("--summary flag cannot be combined with a resource type or actions")
+	}
+	if len(o.contexts) > 0 || o.allContexts {
+		return errors.New("--summary flag cannot be combined with --contexts or --all-contexts flags")
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create discovery client: %w", err)
+	}
+	o.summaryClient, err = dynamic.NewForConfig(o.rest)
 	if err != nil {
 		return fmt.Errorf("unable to create dynamic client: %w", err)
 	}
+	o.typesClient = discoveryClient
+	return nil
+}
+
+// newHandler creates a resource handler with clients built from the given REST config.
+func (o *FindOptions) newHandler(
+	config *rest.Config,
+	resourceType handlers.Resource,
+	handlerOptions handlers.HandlerOptions,
+) (handlers.ResourceHandler, error) {
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kubernetes client: %w", err)
+	}
+
+	dynamic, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create discovery client: %w", err)
+	}
 
-	o.handler, err = handlers.GetResourceHandler(
-		o.resourceType,
-		handlers.NewHandlerOptions().
+	return handlers.GetResourceHandler(
+		resourceType,
+		handlerOptions.
 			WithClientSet(clientSet).
 			WithNamespaced(o.allNamespaces).
 			WithRestarted(o.restarted).
 			WithDynamic(dynamic).
+			WithRESTMapper(restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))).
 			WithImages(o.imageRegex != "").
 			WithLabels(o.showLabels).
 			WithNodeLabels(o.showNodeLabels).
 			WithAnnotations(o.showAnnotations).
+			WithHealth(o.health).
+			WithFlattenToPods(o.flattenToPods).
+			WithServerColumns(o.serverColumns).
+			WithShowOwner(o.showOwner).
+			WithShowConditions(o.showConditions).
+			WithTree(o.tree).
 			WithExecutorGetter(func(method string, url *url.URL) (remotecommand.Executor, error) {
 				return remotecommand.NewSPDYExecutor(
-					o.rest,
+					config,
 					method,
 					url,
 				)
 			}),
 	)
+}
+
+// patching returns true if matched resources are patched with --patch or --patch-template.
+func (o *FindOptions) patching() bool {
+	return o.patch != "" || o.patchTemplate != ""
+}
+
+// validateApplyFrom prepares re-creating resources from a directory of manifests.
+func (o *FindOptions) validateApplyFrom() error {
+	if len(o.args) > 0 || o.delete || o.patching() || o.exec != "" || o.annotate != "" || o.saveTo != "" {
+		return errors.New("--apply-from flag cannot be combined with a resource type or other actions")
+	}
+	if len(o.contexts) > 0 || o.allContexts {
+		return errors.New("--apply-from flag cannot be combined with --contexts or --all-contexts flags")
+	}
+	if info, err := os.Stat(o.applyFrom); err != nil || !info.IsDir() {
+		return fmt.Errorf("--apply-from flag value %q must be an existing directory", o.applyFrom)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create discovery client: %w", err)
+	}
+	dynamic, err := dynamic.NewForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+
+	namespace := o.userSpecifiedNamespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	o.applyOptions = handlers.ApplyFromOptions{
+		Dir:         o.applyFrom,
+		Namespace:   namespace,
+		Client:      dynamic,
+		Mapper:      restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		SkipConfirm: o.skipConfirm,
+		Streams:     &o.IOStreams,
+	}
+	return nil
+}
+
+// parseConditions parses ConditionType=Status filters given to the flag described by kind, e.g. "node condition".
+func parseConditions(values []string, kind string) ([]handlers.NodeCondition, error) {
+	var conditions []handlers.NodeCondition
+	for _, value := range values {
+		conditionType, status, found := strings.Cut(value, "=")
+		if !found || conditionType == "" || status == "" {
+			return nil, fmt.Errorf(
+				"invalid %s format %q, expected ConditionType=Status (e.g. Ready=True)",
+				kind,
+				value,
+			)
+		}
+		conditions = append(conditions, handlers.NodeCondition{
+			Type:   conditionType,
+			Status: status,
+		})
+	}
+	return conditions, nil
+}
+
+// parseConditionAges parses ConditionType=Status:Duration filters given to --condition-age.
+func parseConditionAges(values []string) ([]handlers.ConditionAge, error) {
+	var ages []handlers.ConditionAge
+	for _, value := range values {
+		conditionType, rest, found := strings.Cut(value, "=")
+		status, age, hasAge := strings.Cut(rest, ":")
+		if !found || !hasAge || conditionType == "" || status == "" {
+			return nil, fmt.Errorf(
+				"invalid condition age format %q, expected ConditionType=Status:Duration (e.g. Ready=False:10m)",
+				value,
+			)
+		}
+		minAge, err := time.ParseDuration(age)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition age %q: %w", value, err)
+		}
+		ages = append(ages, handlers.ConditionAge{
+			Type:   conditionType,
+			Status: status,
+			MinAge: minAge,
+		})
+	}
+	return ages, nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *FindOptions) Validate() error {
+	if len(o.currentContext) == 0 && !o.allContexts && len(o.contexts) == 0 {
+		return errNoContext
+	}
+	if o.maxConcurrent < 1 {
+		return fmt.Errorf("invalid --max-concurrent flag value %d, must be at least 1", o.maxConcurrent)
+	}
+
+	if o.applyFrom != "" {
+		return o.validateApplyFrom()
+	}
+
+	if o.listTypes {
+		return o.validateListResourceTypes()
+	}
+	if o.summary {
+		return o.validateSummary()
+	}
+	if o.namespacedOnly || o.typesFilter.apiGroup != nil {
+		return errors.New("--namespaced-only and --api-group flags can only be used with --list-resource-types flag")
+	}
+
+	if o.profile {
+		o.profiler = handlers.NewProfiler()
+	}
+	stopDiscovery := o.profiler.Start(handlers.ProfilePhaseDiscovery)
+
+	var err error
+	if o.allContexts || len(o.contexts) > 0 {
+		if o.allContexts && len(o.contexts) > 0 {
+			return errors.New("cannot specify both --contexts and --all-contexts flags")
+		}
+		if o.configFlags.Context != nil && *o.configFlags.Context != "" {
+			return errors.New("cannot specify --context with --contexts or --all-contexts flags")
+		}
+		if o.targetContexts, err = o.resolveContexts(); err != nil {
+			return fmt.Errorf("invalid --contexts flag value: %w", err)
+		}
+		if err = o.findTargetResource(); err != nil {
+			return err
+		}
+	} else {
+		o.resourceType, err = findResource(o.rest, o.searchType)
+		if err != nil {
+			return fmt.Errorf("unable to find resource type %q: %w", o.searchType, err)
+		}
+	}
+
+	if o.showNamespace && o.noNamespace {
+		return errors.New("cannot specify both --show-namespace and --no-namespace flags")
+	}
+
+	templateColumns, isTemplateColumns := strings.CutPrefix(o.output, outputTemplateColumns)
+	if isTemplateColumns {
+		if o.customColumns != "" || o.serverColumns || o.raw || o.tree {
+			return errors.New("cannot specify --output=template-columns with --custom-columns, --server-columns, --raw or --tree flags")
+		}
+		o.output = ""
+	}
+
+	if o.output != "" && o.output != outputWide && o.output != outputName && o.output != outputJSONLines {
+		return fmt.Errorf("unsupported output format %q, must be one of: %q, %q, %q, %q",
+			o.output, outputWide, outputName, outputJSONLines, outputTemplateColumns+"SPEC")
+	}
+
+	jsonOutput := o.raw || o.output == outputJSONLines
+	if o.raw && o.output != "" && o.output != outputJSONLines {
+		return fmt.Errorf("cannot specify --raw with --output=%s", o.output)
+	}
+	if jsonOutput &&
+		(o.serverColumns || o.customColumns != "" || len(o.selectColumns) > 0 || len(o.hideColumns) > 0 || o.tree) {
+		return errors.New(
+			"cannot specify --raw or --output=jsonl with --server-columns, --custom-columns, --columns, --hide-columns or --tree flags",
+		)
+	}
+	if o.canonicalOrder && !jsonOutput {
+		return errors.New("--canonical-order can only be used with --raw or --output=jsonl")
+	}
+
+	if len(o.selectColumns) > 0 && o.serverColumns {
+		return errors.New("cannot specify both --columns and --server-columns flags")
+	}
+
+	if len(o.hideColumns) > 0 {
+		switch {
+		case len(o.selectColumns) > 0:
+			return errors.New("cannot specify both --columns and --hide-columns flags")
+		case o.serverColumns:
+			return errors.New("cannot specify both --hide-columns and --server-columns flags")
+		case o.output == outputName || o.tree:
+			return errors.New("--hide-columns can only be used with table output")
+		}
+	}
+
+	if o.output == outputName && (o.serverColumns || o.customColumns != "" || len(o.selectColumns) > 0) {
+		return errors.New("cannot specify --output=name with --server-columns, --custom-columns or --columns flags")
+	}
+
+	if o.tree && (o.serverColumns || o.customColumns != "" || len(o.selectColumns) > 0 || o.output != "") {
+		return errors.New("cannot specify --tree with --server-columns, --custom-columns, --columns or --output flags")
+	}
+
+	if _, err = fields.ParseSelector(o.fieldSelector); err != nil {
+		return fmt.Errorf("invalid --field-selector flag value: %w", err)
+	}
+
+	var listOptions metav1.ListOptions
+	if o.listOptions != "" {
+		if listOptions, err = handlers.ParseListOptions(o.listOptions); err != nil {
+			return fmt.Errorf("invalid --list-options flag value: %w", err)
+		}
+	}
+
+	handlerOptions := handlers.NewHandlerOptions().
+		WithWide(o.output == outputWide).
+		WithNameOutput(o.output == outputName).
+		WithRawOutput(o.raw).
+		WithJSONLines(o.output == outputJSONLines).
+		WithCanonicalOrder(o.canonicalOrder).
+		WithSelectColumns(o.selectColumns).
+		WithHideColumns(o.hideColumns)
+	if o.alsoJSON != "" {
+		o.alsoJSONFile = &jsonFile{path: o.alsoJSON}
+		handlerOptions = handlerOptions.WithAlsoJSON(o.alsoJSONFile)
+	}
+	if o.customColumns != "" {
+		if o.serverColumns {
+			return errors.New("cannot specify both --custom-columns and --server-columns flags")
+		}
+		customColumns, columnsErr := handlers.ParseCustomColumns(o.customColumns)
+		if columnsErr != nil {
+			return fmt.Errorf("invalid --custom-columns flag value: %w", columnsErr)
+		}
+		handlerOptions = handlerOptions.WithCustomColumns(customColumns)
+	}
+	if isTemplateColumns {
+		columns, columnsErr := handlers.ParseTemplateColumns(templateColumns)
+		if columnsErr != nil {
+			return fmt.Errorf("invalid --output=template-columns flag value: %w", columnsErr)
+		}
+		handlerOptions = handlerOptions.WithCustomColumns(columns)
+	}
+	if o.showNamespace || o.noNamespace {
+		handlerOptions = handlerOptions.WithShowNamespace(o.showNamespace)
+	}
+	if len(o.targetContexts) > 1 {
+		handlerOptions = handlerOptions.WithContextName(o.targetContexts[0])
+	}
+
+	if o.forService != "" {
+		if err = o.resolveForService(context.Background()); err != nil {
+			return err
+		}
+	}
+	if o.selectorFrom != "" {
+		if err = o.resolveSelectorFrom(context.Background()); err != nil {
+			return err
+		}
+	}
+	if o.olderThan != "" || o.newerThan != "" {
+		if err = o.resolveAgeReferences(context.Background()); err != nil {
+			return err
+		}
+	}
+
+	// server tables are requested without --on-node, rows of pods on other nodes are skipped when printing
+	handlerOptions = handlerOptions.WithListOptions(
+		handlers.ListOptionsWithSelectors(listOptions, o.labelSelector, o.fieldSelector))
+	o.handlerOptions = handlerOptions
+	o.handler, err = o.newHandler(o.rest, o.resourceType, handlerOptions)
 	if err != nil {
 		return fmt.Errorf("unable to create resource handler for type %s: %w", o.resourceType.SingularName, err)
 	}
+	stopDiscovery(0)
 
 	if o.handler == nil {
 		return fmt.Errorf("no handler found for resource type %s", o.resourceType.SingularName)
@@ -356,14 +1101,25 @@ func (o *FindOptions) Validate() error {
 	if o.delete {
 		action = handlers.ActionDelete
 	}
-	if o.patch != "" {
+	var patchTemplate *template.Template
+	if o.patching() {
 		if o.delete {
 			return errors.New("cannot specify both --delete and --patch flags")
 		}
+		if o.patch != "" && o.patchTemplate != "" {
+			return errors.New("cannot specify both --patch and --patch-template flags")
+		}
+		if o.patchTemplate != "" {
+			var err2 error
+			patchTemplate, err2 = template.New("patch").Option("missingkey=error").Parse(o.patchTemplate)
+			if err2 != nil {
+				return fmt.Errorf("invalid --patch-template flag value: %w", err2)
+			}
+		}
 		action = handlers.ActionPatch
 	}
 	if o.exec != "" {
-		if o.delete || o.patch != "" {
+		if o.delete || o.patching() {
 			return errors.New("cannot specify both --delete or --patch and --exec flags")
 		}
 		if o.resourceType.GroupVersionResource != handlers.PodType {
@@ -374,7 +1130,7 @@ func (o *FindOptions) Validate() error {
 
 	var annotateCfg handlers.AnnotateConfig
 	if o.annotate != "" {
-		if o.delete || o.patch != "" || o.exec != "" {
+		if o.delete || o.patching() || o.exec != "" {
 			return errors.New("cannot combine --annotate with --delete, --patch, or --exec flags")
 		}
 		var err2 error
@@ -385,9 +1141,79 @@ func (o *FindOptions) Validate() error {
 		action = handlers.ActionAnnotate
 	}
 
+	var execEachTemplate handlers.CommandTemplate
+	if o.execEach != "" {
+		if o.delete || o.patching() || o.exec != "" || o.annotate != "" {
+			return errors.New("cannot combine --exec-each with --delete, --patch, --exec or --annotate flags")
+		}
+		var err2 error
+		execEachTemplate, err2 = handlers.ParseCommandTemplate(o.execEach)
+		if err2 != nil {
+			return fmt.Errorf("invalid --exec-each flag value: %w", err2)
+		}
+		action = handlers.ActionExecEach
+	}
+
+	if len(o.targetContexts) > 0 && action != handlers.ActionList {
+		return fmt.Errorf("--contexts and --all-contexts flags can only be used to list resources, but got %s action", action)
+	}
+
+	if o.diff && action != handlers.ActionPatch {
+		return errors.New("--diff flag can only be used with --patch flag")
+	}
+
+	var patchType k8s_types.PatchType
+	if o.patchType != "" {
+		if action != handlers.ActionPatch {
+			return errors.New("--patch-type flag can only be used with --patch flag")
+		}
+		var err2 error
+		if patchType, err2 = handlers.ParsePatchType(o.patchType); err2 != nil {
+			return fmt.Errorf("invalid --patch-type flag value: %w", err2)
+		}
+	}
+	if o.forceConflicts && patchType != k8s_types.ApplyPatchType {
+		return errors.New("--force-conflicts flag can only be used with --patch-type=apply")
+	}
+	if o.diff && patchType == k8s_types.ApplyPatchType {
+		return errors.New("--diff flag is not supported with --patch-type=apply")
+	}
+	if o.previewDiff {
+		switch {
+		case action != handlers.ActionPatch:
+			return errors.New("--preview-diff flag can only be used with --patch flag")
+		case o.diff:
+			return errors.New("cannot specify both --diff and --preview-diff flags")
+		case o.skipConfirm:
+			return errors.New("--preview-diff flag shows diffs in the confirmation prompt and cannot be used with --skip-confirm")
+		case patchType == k8s_types.ApplyPatchType:
+			return errors.New("--preview-diff flag is not supported with --patch-type=apply")
+		}
+	}
+
+	if (o.dryRun || o.count || o.includeNotReady) && action != handlers.ActionExec {
+		return errors.New("--dry-run, --count and --include-not-ready flags can only be used with --exec flag")
+	}
+
+	ignoreForbidden := action == handlers.ActionList
+	if o.forbiddenSet {
+		ignoreForbidden = o.ignoreForbidden
+	}
+
 	if o.force && action != handlers.ActionDelete {
 		return errors.New("--force flag can only be used with --delete flag")
 	}
+	if o.evict {
+		if action != handlers.ActionDelete {
+			return errors.New("--evict flag can only be used with --delete flag")
+		}
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("eviction is only supported for pods, but got %q", o.resourceType.GroupVersionResource.String())
+		}
+	}
+	if o.evictTimeout < 0 {
+		return fmt.Errorf("invalid --evict-timeout flag value %s, must not be negative", o.evictTimeout)
+	}
 
 	if action == handlers.ActionExec && !o.handler.IsExecutable() {
 		return fmt.Errorf("resource type %q does not support execution",
@@ -402,6 +1228,15 @@ func (o *FindOptions) Validate() error {
 		}
 	}
 
+	nameExclude := make([]*regexp.Regexp, 0, len(o.nameExclude))
+	for _, pattern := range o.nameExclude {
+		var exclude *regexp.Regexp
+		if exclude, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid name exclude regex %q: %w", pattern, err)
+		}
+		nameExclude = append(nameExclude, exclude)
+	}
+
 	var minAge, maxAge time.Duration
 
 	if o.minAge != "" {
@@ -415,14 +1250,116 @@ func (o *FindOptions) Validate() error {
 		}
 	}
 
+	var podPhase v1.PodPhase
+	var phase string
 	if o.podStatus != "" {
+		if o.resourceType.GroupVersionResource == handlers.PodType {
+			if !handlers.IsValidPodStatus(o.podStatus) {
+				return fmt.Errorf("invalid pod status %q, must be one of: %v", o.podStatus, handlers.ValidPodStatuses)
+			}
+			podPhase = handlers.ToPodPhase(o.podStatus)
+		} else {
+			phase = o.podStatus
+		}
+	}
+
+	if o.minRestarts != 0 {
+		if o.minRestarts < 0 {
+			return fmt.Errorf("invalid --min-restarts flag value %d, must not be negative", o.minRestarts)
+		}
 		if o.resourceType.GroupVersionResource != handlers.PodType {
-			return fmt.Errorf("status filtering is only supported for pods, but got %q",
+			return fmt.Errorf("restart count filtering is only supported for pods, but got %q",
 				o.resourceType.GroupVersionResource.String())
 		}
-		if !handlers.IsValidPodStatus(o.podStatus) {
-			return fmt.Errorf("invalid pod status %q, must be one of: %v", o.podStatus, handlers.ValidPodStatuses)
+	}
+
+	if o.restartReason != "" && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("restart reason filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+
+	var exitCode *int32
+	if o.exitCodeSet || o.exitCodeNonzero {
+		if o.exitCodeSet && o.exitCodeNonzero {
+			return errors.New("cannot specify both --exit-code and --exit-code-nonzero flags")
+		}
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("exit code filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if o.exitCodeSet {
+			exitCode = &o.exitCode
+		}
+	}
+
+	if o.completed || o.failedJob {
+		switch {
+		case o.completed && o.failedJob:
+			return errors.New("cannot specify both --completed and --failed-job flags")
+		case o.completed && o.resourceType.GroupVersionResource == handlers.PodType:
+			if o.podStatus != "" {
+				return errors.New("cannot specify both --completed and --status flags")
+			}
+			podPhase = v1.PodSucceeded
+		case o.completed && o.resourceType.GroupVersionResource != handlers.JobType:
+			return fmt.Errorf("--completed flag is only supported for jobs and pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		case o.failedJob && o.resourceType.GroupVersionResource != handlers.JobType:
+			return fmt.Errorf("--failed-job flag is only supported for jobs, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+	}
+
+	if o.listImages {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("listing images is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
 		}
+		if action != handlers.ActionList {
+			return fmt.Errorf("--list-images flag can only be used to list resources, but got %s action", action)
+		}
+	}
+
+	if o.alsoJSON != "" && (action != handlers.ActionList || o.listImages || o.restartTimeline || o.colocated) {
+		return errors.New("--also-json flag can only be used to list resources, " +
+			"without --list-images, --restart-timeline or --colocated-on-node flags")
+	}
+
+	if o.countByNS && !o.resourceType.IsNamespaced {
+		return fmt.Errorf("--count-by-namespace flag can only be used with namespaced resources, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+
+	if o.restartTimeline {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("restart timeline is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if action != handlers.ActionList || o.listImages {
+			return errors.New("--restart-timeline flag can only be used to list resources, without --list-images flag")
+		}
+	}
+
+	if o.colocated {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("colocation report is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if action != handlers.ActionList || o.listImages || o.restartTimeline {
+			return errors.New("--colocated-on-node flag can only be used to list resources, " +
+				"without --list-images or --restart-timeline flags")
+		}
+	}
+
+	if (o.watch || o.watchOnly) &&
+		(action != handlers.ActionList || len(o.targetContexts) > 0 ||
+			o.listImages || o.restartTimeline || o.colocated || o.raw || o.tree) {
+		return errors.New("--watch and --watch-only flags can only be used to list resources in a single context, " +
+			"without --list-images, --restart-timeline, --colocated-on-node, --raw or --tree flags")
+	}
+
+	if jsonOutput && action != handlers.ActionList {
+		return fmt.Errorf("--raw and --output=jsonl flags can only be used to list resources, but got %s action", action)
 	}
 
 	if o.showNodeLabels != nil && o.resourceType.GroupVersionResource != handlers.PodType {
@@ -441,6 +1378,54 @@ func (o *FindOptions) Validate() error {
 		}
 	}
 
+	if (o.onNode != "" || o.reschedulable) && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("node filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+
+	var podIP net.IP
+	var podIPRange *net.IPNet
+	if o.podIP != "" || o.podIPCIDR != "" {
+		if o.podIP != "" && o.podIPCIDR != "" {
+			return errors.New("cannot specify both --pod-ip and --pod-ip-cidr flags")
+		}
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("pod IP filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if o.podIP != "" {
+			if podIP = net.ParseIP(o.podIP); podIP == nil {
+				return fmt.Errorf("invalid --pod-ip flag value %q, expected an IP address", o.podIP)
+			}
+		}
+		if o.podIPCIDR != "" {
+			if _, podIPRange, err = net.ParseCIDR(o.podIPCIDR); err != nil {
+				return fmt.Errorf("invalid --pod-ip-cidr flag value: %w", err)
+			}
+		}
+	}
+
+	var hostIP net.IP
+	if o.hostIP != "" {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("host IP filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if hostIP = net.ParseIP(o.hostIP); hostIP == nil {
+			return fmt.Errorf("invalid --host-ip flag value %q, expected an IP address", o.hostIP)
+		}
+	}
+
+	if o.standalone || o.controlled {
+		if o.standalone && o.controlled {
+			return errors.New("cannot specify both --standalone and --controlled flags")
+		}
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("controller filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+	}
+
 	var imagesRegex *regexp.Regexp
 	if o.imageRegex != "" {
 		if o.resourceType.GroupVersionResource != handlers.PodType {
@@ -451,6 +1436,46 @@ func (o *FindOptions) Validate() error {
 			return fmt.Errorf("invalid image regex filter %q: %w", o.imageRegex, err)
 		}
 	}
+	var containerRegex *regexp.Regexp
+	if o.containerName != "" {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("container name filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if containerRegex, err = regexp.Compile(o.containerName); err != nil {
+			return fmt.Errorf("invalid container name regex filter %q: %w", o.containerName, err)
+		}
+	}
+	var imageRegistry string
+	if o.imageRegistry != "" {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("image registry filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if imageRegistry, err = handlers.ParseImageRegistry(o.imageRegistry); err != nil {
+			return fmt.Errorf("invalid --image-registry flag value: %w", err)
+		}
+	}
+	imageTag := o.imageTag
+	if o.latest {
+		if imageTag != "" {
+			return errors.New("cannot specify both --image-tag and --latest flags")
+		}
+		imageTag = "latest"
+	}
+	if imageTag != "" && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("image tag filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+	if o.byDigest || o.noDigest {
+		if o.byDigest && o.noDigest {
+			return errors.New("cannot specify both --by-digest and --no-digest flags")
+		}
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("image digest filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+	}
 	var jqQuery *gojq.Query
 	if o.jqFilter != "" {
 		jqQuery, err = pkg.PrepareQuery(o.jqFilter)
@@ -470,18 +1495,125 @@ func (o *FindOptions) Validate() error {
 				o.resourceType.GroupVersionResource.String(),
 			)
 		}
-		for _, nc := range o.nodeConditions {
-			parts := strings.SplitN(nc, "=", 2) //nolint:mnd // split into key=value pair
-			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-				return fmt.Errorf(
-					"invalid node condition format %q, expected ConditionType=Status (e.g. Ready=True)",
-					nc,
-				)
+		if nodeConditions, err = parseConditions(o.nodeConditions, "node condition"); err != nil {
+			return err
+		}
+	}
+
+	conditions, err := parseConditions(o.conditions, "condition")
+	if err != nil {
+		return err
+	}
+
+	conditionAges, err := parseConditionAges(o.conditionAges)
+	if err != nil {
+		return err
+	}
+
+	labelCompare := make([]handlers.LabelComparison, 0, len(o.labelCompare))
+	for _, expr := range o.labelCompare {
+		comparison, parseErr := handlers.ParseLabelComparison(expr)
+		if parseErr != nil {
+			return fmt.Errorf("invalid --label-compare flag value: %w", parseErr)
+		}
+		labelCompare = append(labelCompare, comparison)
+	}
+
+	var envVars []handlers.EnvVarFilter
+	if len(o.envVars) > 0 {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("environment variable filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		for _, env := range o.envVars {
+			name, value, hasValue := strings.Cut(env, "=")
+			if name == "" {
+				return fmt.Errorf("invalid environment variable filter %q, expected KEY=VALUE or KEY", env)
 			}
-			nodeConditions = append(nodeConditions, handlers.NodeCondition{
-				Type:   parts[0],
-				Status: parts[1],
-			})
+			envVars = append(envVars, handlers.EnvVarFilter{Name: name, Value: value, HasValue: hasValue})
+		}
+	}
+
+	var volumeType string
+	if o.volumeType != "" || o.volumeName != "" {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("volume filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		if o.volumeType != "" {
+			if volumeType, err = handlers.ParseVolumeType(o.volumeType); err != nil {
+				return fmt.Errorf("invalid --volume-type flag value: %w", err)
+			}
+		}
+	}
+
+	if o.usesSecret != "" && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("secret usage filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+	if o.usesConfigMap != "" && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("config map usage filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+	if o.usesPVC != "" && o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("claim usage filtering is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+
+	var requiredLabel handlers.NodeLabel
+	if o.requiresLabel != "" {
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("node label requirement filtering is only supported for pods, but got %q",
+				o.resourceType.GroupVersionResource.String())
+		}
+		key, value, found := strings.Cut(o.requiresLabel, "=")
+		if !found || key == "" {
+			return fmt.Errorf("invalid --requires-node-label flag value %q, expected KEY=VALUE", o.requiresLabel)
+		}
+		requiredLabel = handlers.NodeLabel{Key: key, Value: value}
+	}
+
+	switch o.sortBy {
+	case "", handlers.SortByAge:
+	case handlers.SortByRestarts, handlers.SortByStatus:
+		if o.resourceType.GroupVersionResource != handlers.PodType {
+			return fmt.Errorf("sorting by %s is only supported for pods, but got %q",
+				o.sortBy, o.resourceType.GroupVersionResource.String())
+		}
+	default:
+		return fmt.Errorf("unsupported sort order %q, must be one of: %q, %q, %q",
+			o.sortBy, handlers.SortByAge, handlers.SortByRestarts, handlers.SortByStatus)
+	}
+	if o.sortBy != "" && o.naturalSort {
+		return errors.New("cannot specify both --sort and --natural-sort flags")
+	}
+	if o.reverse && o.sortBy == "" && !o.naturalSort {
+		return errors.New("--reverse flag can only be used with --sort or --natural-sort flags")
+	}
+
+	if o.health && !handlers.IsWorkloadType(o.resourceType.GroupVersionResource) {
+		return fmt.Errorf(
+			"health filtering is only supported for deployments, statefulsets and daemonsets, but got %q",
+			o.resourceType.GroupVersionResource.String(),
+		)
+	}
+
+	if o.zeroReplicas && !handlers.IsScalableType(o.resourceType.GroupVersionResource) {
+		return fmt.Errorf(
+			"zero replicas filtering is only supported for deployments, statefulsets and replicasets, but got %q",
+			o.resourceType.GroupVersionResource.String(),
+		)
+	}
+
+	if o.flattenToPods {
+		if !handlers.IsWorkloadType(o.resourceType.GroupVersionResource) {
+			return fmt.Errorf(
+				"flattening to pods is only supported for deployments, statefulsets and daemonsets, but got %q",
+				o.resourceType.GroupVersionResource.String(),
+			)
+		}
+		if action != handlers.ActionList || o.watch || o.watchOnly || o.tree {
+			return errors.New("--flatten-to-pods flag can only be used to list resources, without --watch or --tree flags")
 		}
 	}
 
@@ -489,26 +1621,98 @@ func (o *FindOptions) Validate() error {
 		Namespace:       o.userSpecifiedNamespace,
 		Action:          action,
 		NameRegex:       reg,
+		NameExclude:     nameExclude,
 		MaxAge:          maxAge,
 		MinAge:          minAge,
 		LabelSelector:   o.labelSelector, // todo: add validation for label selector
+		FieldSelector:   o.fieldSelector,
+		ListOptions:     listOptions,
+		HintOnEmpty:     o.hintOnEmpty,
 		Streams:         &o.IOStreams,
 		JQQuery:         jqQuery,
 		NodeNameRegex:   nodeNameRegex,
+		NodeName:        o.onNode,
+		PodIP:           podIP,
+		PodIPRange:      podIPRange,
+		HostIP:          hostIP,
+		Reschedulable:   o.reschedulable,
+		Standalone:      o.standalone,
+		Controlled:      o.controlled,
 		SkipConfirm:     o.skipConfirm,
 		Force:           o.force,
-		PodStatus:       handlers.ToPodPhase(o.podStatus),
+		Evict:           o.evict || o.reschedulable, // a drain evicts the pods
+		EvictTimeout:    o.evictTimeout,
+		PodStatus:       podPhase,
+		Phase:           phase,
 		Exec:            o.exec,
+		IncludeNotReady: o.includeNotReady,
+		DryRun:          o.dryRun,
+		Count:           o.count,
 		Patch:           o.patch,
+		PatchTemplate:   patchTemplate,
+		ExecEach:        execEachTemplate,
+		IgnoreForbidden: ignoreForbidden,
+		PatchStrategy:   patchType,
+		ForceConflicts:  o.forceConflicts,
+		Diff:            o.diff,
+		PreviewDiff:     o.previewDiff,
+		SaveTo:          o.saveTo,
 		Annotate:        annotateCfg,
 		ResourceType:    o.resourceType,
 		Restarted:       o.restarted,
+		MinRestarts:     o.minRestarts,
+		RestartReason:   o.restartReason,
+		ExitCode:        exitCode,
+		ExitCodeNonzero: o.exitCodeNonzero,
 		ImageRegex:      imagesRegex,
+		ContainerRegex:  containerRegex,
+		ImageRegistry:   imageRegistry,
+		ImageTag:        imageTag,
+		ByDigest:        o.byDigest,
+		NoDigest:        o.noDigest,
 		ShowNodeLabels:  o.showNodeLabels,
+		ListImages:      o.listImages,
+		RestartTimeline: o.restartTimeline,
+		Colocated:       o.colocated,
 		ShowLabels:      o.showLabels,
 		ShowAnnotations: o.showAnnotations,
 		NaturalSort:     o.naturalSort,
+		SortBy:          o.sortBy,
+		ReverseSort:     o.reverse,
+		Watch:           o.watch || o.watchOnly,
+		WatchOnly:       o.watchOnly,
 		NodeConditions:  nodeConditions,
+		Conditions:      conditions,
+		ConditionAges:   conditionAges,
+		LabelCompare:    labelCompare,
+		EnvVars:         envVars,
+		VolumeType:      volumeType,
+		VolumeName:      o.volumeName,
+		UsesSecret:      o.usesSecret,
+		UsesPVC:         o.usesPVC,
+		UsesConfigMap:   o.usesConfigMap,
+		RequiredLabel:   requiredLabel,
+		Health:          o.health,
+		ZeroReplicas:    o.zeroReplicas,
+		CreatedBefore:   o.createdBefore,
+		CreatedAfter:    o.createdAfter,
+		Completed:       o.completed && o.resourceType.GroupVersionResource == handlers.JobType,
+		FailedJob:       o.failedJob,
+		Stale:           o.stale,
+	}
+	if o.progressJSON {
+		o.options.Progress = handlers.NewJSONProgressReporter(o.ErrOut)
+	}
+	o.options.Profiler = o.profiler
+	o.options.FallbackNamespace = o.kubeconfigNamespace(o.currentContext)
+	if o.stats {
+		o.options.Stats = handlers.NewMatchStats()
+	}
+	if o.countByNS {
+		o.options.ByNamespace = handlers.NewNamespaceCounts()
+	}
+	if o.auditLog != "" {
+		o.options.Audit = handlers.NewAuditTrail(o.currentContext)
 	}
 
 	return nil
@@ -522,8 +1726,75 @@ func (t Title) Format() string {
 
 // Run finds all resources of a specified type matching the provided criteria
 // and optionally performs an action on them.
-func (o *FindOptions) Run() error {
+func (o *FindOptions) Run() (err error) {
 	ctx := context.Background()
 
+	if o.saveName != "" {
+		if err := o.saveQuery(); err != nil {
+			return err
+		}
+	}
+
+	if o.options.Audit != nil {
+		startedAt := time.Now()
+		defer func() {
+			if auditErr := o.writeAuditLog(startedAt, err); auditErr != nil {
+				if err != nil {
+					fmt.Fprintf(o.ErrOut, "Warning: %v\n", auditErr)
+					return
+				}
+				err = auditErr
+			}
+		}()
+	}
+
+	if o.profiler != nil {
+		defer func() {
+			if err := o.profiler.Print(o.ErrOut); err != nil {
+				fmt.Fprintf(o.ErrOut, "Warning: failed to print profile: %v\n", err)
+			}
+		}()
+	}
+	if o.options.Stats != nil {
+		defer func() {
+			if err := o.options.Stats.Print(o.ErrOut); err != nil {
+				fmt.Fprintf(o.ErrOut, "Warning: failed to print stats: %v\n", err)
+			}
+		}()
+	}
+	if o.options.ByNamespace != nil {
+		defer func() {
+			if err := o.options.ByNamespace.Print(o.ErrOut); err != nil {
+				fmt.Fprintf(o.ErrOut, "Warning: failed to print namespace counts: %v\n", err)
+			}
+		}()
+	}
+
+	if o.applyFrom != "" {
+		return handlers.ApplyFromDirectory(ctx, o.applyOptions)
+	}
+
+	if o.alsoJSONFile != nil {
+		if err = o.alsoJSONFile.open(); err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := o.alsoJSONFile.close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	if o.listTypes {
+		return printResourceTypes(o.typesClient, o.typesFilter, o.Out, o.ErrOut)
+	}
+	if o.summary {
+		return printNamespaceSummary(ctx, o.typesClient, o.summaryClient, o.userSpecifiedNamespace, o.maxConcurrent, o.Out, o.ErrOut)
+	}
+
+	if len(o.targetContexts) > 0 {
+		return o.runContexts(ctx, o.targetContexts)
+	}
+
 	return o.handler.HandleAction(ctx, o.options)
 }
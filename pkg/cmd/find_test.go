@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const clustersKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster-a
+  cluster:
+    server: https://a.example.com
+- name: cluster-b
+  cluster:
+    server: https://b.example.com
+users:
+- name: user
+  user:
+    token: secret
+contexts:
+- name: ctx-a
+  context:
+    cluster: cluster-a
+    user: user
+    namespace: ns-a
+`
+
+const currentContextKubeconfig = `apiVersion: v1
+kind: Config
+current-context: ctx-b
+contexts:
+- name: ctx-b
+  context:
+    cluster: cluster-b
+    user: user
+    namespace: ns-b
+`
+
+func writeKubeconfigs(t *testing.T, contents ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 0, len(contents))
+	for i, content := range contents {
+		path := filepath.Join(dir, "config-"+string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		paths = append(paths, path)
+	}
+	t.Setenv("KUBECONFIG", strings.Join(paths, string(filepath.ListSeparator)))
+}
+
+func newTestFindOptions(t *testing.T, flags ...string) (*FindOptions, *cobra.Command) {
+	t.Helper()
+	streams, _, _, _ := genericiooptions.NewTestIOStreams()
+	o := NewFindOptions(streams)
+	cmd := &cobra.Command{}
+	o.configFlags.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "")
+	require.NoError(t, cmd.Flags().Parse(flags))
+	return o, cmd
+}
+
+func TestComplete_MergedKubeconfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		flags         []string
+		wantContext   string
+		wantNamespace string
+	}{
+		{
+			name:          "current context from second file",
+			wantContext:   "ctx-b",
+			wantNamespace: "ns-b",
+		},
+		{
+			name:          "context flag overrides merged current context",
+			flags:         []string{"--context", "ctx-a"},
+			wantContext:   "ctx-a",
+			wantNamespace: "ns-a",
+		},
+		{
+			name:          "namespace flag wins over context namespace",
+			flags:         []string{"--context", "ctx-a", "-n", "custom"},
+			wantContext:   "ctx-a",
+			wantNamespace: "custom",
+		},
+		{
+			name:          "all namespaces",
+			flags:         []string{"-A"},
+			wantContext:   "ctx-b",
+			wantNamespace: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeKubeconfigs(t, clustersKubeconfig, currentContextKubeconfig)
+			o, cmd := newTestFindOptions(t, tt.flags...)
+
+			require.NoError(t, o.Complete(cmd, nil))
+			assert.Equal(t, tt.wantContext, o.currentContext)
+			assert.Equal(t, tt.wantNamespace, o.userSpecifiedNamespace)
+			assert.Equal(t, "https://"+strings.TrimPrefix(tt.wantContext, "ctx-")+".example.com", o.rest.Host)
+		})
+	}
+}
+
+type profiledHandler struct{}
+
+func (profiledHandler) IsExecutable() bool { return false }
+
+func (profiledHandler) HandleAction(_ context.Context, options handlers.ActionOptions) error {
+	options.Profiler.Start(handlers.ProgressPhaseListing)(3)
+	return nil
+}
+
+func TestRun_Profile(t *testing.T) {
+	streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	o := NewFindOptions(streams)
+	o.profiler = handlers.NewProfiler()
+	o.handler = profiledHandler{}
+	o.options = handlers.ActionOptions{Profiler: o.profiler}
+
+	require.NoError(t, o.Run())
+	assert.Empty(t, out.String(), "profile must not be written to stdout")
+
+	lines := strings.Split(strings.TrimSpace(errOut.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"PHASE", "DURATION", "OBJECTS"}, strings.Fields(lines[0]))
+	assert.Equal(t, "listing", strings.Fields(lines[1])[0])
+	assert.Equal(t, "3", strings.Fields(lines[1])[2])
+	assert.Equal(t, "total", strings.Fields(lines[2])[0])
+}
+
+func TestResolveResource(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "batch.example.com", Version: "v1", Kind: "CronTab"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "VirtualService"}, meta.RESTScopeNamespace)
+
+	client := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "pods", SingularName: "pod", Namespaced: true, Kind: "Pod"}},
+				},
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", SingularName: "deployment", Namespaced: true, Kind: "Deployment"},
+					},
+				},
+				{
+					GroupVersion: "networking.k8s.io/v1",
+					APIResources: []metav1.APIResource{{Name: "ingresses", SingularName: "ingress", Namespaced: true, Kind: "Ingress"}},
+				},
+				{
+					GroupVersion: "stable.example.com/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "crontabs", SingularName: "crontab", ShortNames: []string{"ct"}, Namespaced: true, Kind: "CronTab"},
+					},
+				},
+				{
+					GroupVersion: "batch.example.com/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "crontabs", SingularName: "crontab", ShortNames: []string{"ct"}, Namespaced: true, Kind: "CronTab"},
+					},
+				},
+				{
+					GroupVersion: "networking.istio.io/v1",
+					APIResources: []metav1.APIResource{{
+						Name:         "virtualservices",
+						SingularName: "virtualservice",
+						ShortNames:   []string{"vs"},
+						Namespaced:   true,
+						Kind:         "VirtualService",
+					}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		want     handlers.Resource
+		wantErr  string
+	}{
+		{
+			name:     "pods",
+			resource: "pods",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				GroupVersionKind:     schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				PluralName:           "pods",
+				SingularName:         "pod",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "pod name is ignored",
+			resource: "pods/web-1",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+				GroupVersionKind:     schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+				PluralName:           "pods",
+				SingularName:         "pod",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "resource with group",
+			resource: "deployments.apps",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+				GroupVersionKind:     schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				PluralName:           "deployments",
+				SingularName:         "deployment",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "resource with dotted group",
+			resource: "crontabs.stable.example.com",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "crontabs"},
+				GroupVersionKind:     schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"},
+				PluralName:           "crontabs",
+				SingularName:         "crontab",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "resource with version and group",
+			resource: "ingresses.v1.networking.k8s.io",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+				GroupVersionKind:     schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+				PluralName:           "ingresses",
+				SingularName:         "ingress",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "custom resource short name",
+			resource: "vs",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1", Resource: "virtualservices"},
+				GroupVersionKind:     schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "VirtualService"},
+				PluralName:           "virtualservices",
+				SingularName:         "virtualservice",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "short name shared by custom resources",
+			resource: "ct",
+			want: handlers.Resource{
+				GroupVersionResource: schema.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "crontabs"},
+				GroupVersionKind:     schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"},
+				PluralName:           "crontabs",
+				SingularName:         "crontab",
+				IsNamespaced:         true,
+			},
+		},
+		{
+			name:     "unknown short name",
+			resource: "xyz",
+			wantErr:  `unable to resolve resource xyz: no matches for /, Resource=xyz`,
+		},
+		{
+			name:     "ambiguous custom resource",
+			resource: "crontabs",
+			wantErr: `resource type "crontabs" is ambiguous, use one of the fully qualified names: ` +
+				"crontabs.batch.example.com, crontabs.stable.example.com",
+		},
+		{
+			name:     "ambiguous resource",
+			resource: "ingresses",
+			wantErr: `resource type "ingresses" is ambiguous, use one of the fully qualified names: ` +
+				"ingresses.extensions, ingresses.networking.k8s.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveResource(client, restmapper.NewShortcutExpander(mapper, client, nil), tt.resource)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type countedHandler struct{}
+
+func (countedHandler) IsExecutable() bool { return false }
+
+func (countedHandler) HandleAction(_ context.Context, options handlers.ActionOptions) error {
+	options.Stats.Add(12000, 37)
+	return nil
+}
+
+func TestRun_Stats(t *testing.T) {
+	streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	o := NewFindOptions(streams)
+	o.handler = countedHandler{}
+	o.options = handlers.ActionOptions{Stats: handlers.NewMatchStats()}
+
+	require.NoError(t, o.Run())
+	assert.Empty(t, out.String(), "stats must not be written to stdout")
+	assert.Equal(t, "fetched 12000, matched 37\n", errOut.String())
+}
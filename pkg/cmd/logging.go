@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	goflag "flag"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+// addVerbosityFlag registers klog's -v/--v flag. Logs are written to stderr and are disabled by default.
+func addVerbosityFlag(flags *pflag.FlagSet) {
+	klogFlags := goflag.NewFlagSet("klog", goflag.ContinueOnError)
+	klog.InitFlags(klogFlags)
+
+	verbosity := pflag.PFlagFromGoFlag(klogFlags.Lookup("v"))
+	verbosity.Shorthand = "v"
+	verbosity.Usage = "Log level for debug logs written to stderr: " +
+		"2 resolved resource types, 3 listed pages, 5 matcher decisions, 6 and above API calls with timings."
+	flags.AddFlag(verbosity)
+}
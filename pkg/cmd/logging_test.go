@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/klog/v2"
+)
+
+func TestAddVerbosityFlag(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	addVerbosityFlag(flags)
+	t.Cleanup(func() {
+		require.NoError(t, flags.Set("v", "0"))
+	})
+
+	assert.False(t, klog.V(1).Enabled(), "logs must be disabled by default")
+
+	require.NoError(t, flags.Parse([]string{"-v", "5"}))
+	assert.True(t, klog.V(5).Enabled())
+	assert.False(t, klog.V(6).Enabled())
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"k8s.io/client-go/dynamic"
+)
+
+// creationTime returns the creation timestamp of the named object.
+func creationTime(
+	ctx context.Context,
+	client dynamic.Interface,
+	resource handlers.Resource,
+	namespace, name string,
+) (time.Time, error) {
+	obj, err := getObject(ctx, client, resource, namespace, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return obj.GetCreationTimestamp().Time, nil
+}
+
+// resolveAgeReferences gets the creation time of the objects given by --older-than and --newer-than.
+func (o *FindOptions) resolveAgeReferences(ctx context.Context) error {
+	if len(o.targetContexts) > 0 {
+		return errors.New("--older-than and --newer-than flags cannot be combined with --contexts or --all-contexts flags")
+	}
+	client, err := dynamic.NewForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+
+	resolve := func(flag, value string) (time.Time, error) {
+		if value == "" {
+			return time.Time{}, nil
+		}
+		searchType, name, err := parseTypeName(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --%s flag value: %w", flag, err)
+		}
+		resource, err := findResource(o.rest, searchType)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to find resource type %q: %w", searchType, err)
+		}
+		if resource.IsNamespaced && o.allNamespaces {
+			return time.Time{}, fmt.Errorf("--%s flag needs a namespace to get %s %s, it cannot be combined with --all-namespaces",
+				flag, resource.SingularName, name)
+		}
+		return creationTime(ctx, client, resource, o.userSpecifiedNamespace, name)
+	}
+
+	if o.createdBefore, err = resolve("older-than", o.olderThan); err != nil {
+		return err
+	}
+	o.createdAfter, err = resolve("newer-than", o.newerThan)
+	return err
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestCreationTime(t *testing.T) {
+	deploymentType := handlers.Resource{
+		GroupVersionResource: handlers.DeploymentType,
+		SingularName:         "deployment",
+		PluralName:           "deployments",
+		IsNamespaced:         true,
+	}
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:              "web",
+		Namespace:         "shop",
+		CreationTimestamp: metav1.NewTime(created),
+	}})
+
+	createdAt, err := creationTime(t.Context(), client, deploymentType, "shop", "web")
+	require.NoError(t, err)
+	assert.True(t, created.Equal(createdAt))
+
+	_, err = creationTime(t.Context(), client, deploymentType, "default", "web")
+	require.EqualError(t, err, `failed to get deployment web: deployments.apps "web" not found`)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// resourceTypesFilter selects resource types printed by --list-resource-types.
+type resourceTypesFilter struct {
+	namespacedOnly bool
+	apiGroup       *string // nil matches all groups, "" matches the core group
+}
+
+type resourceType struct {
+	groupVersion schema.GroupVersion
+	resource     metav1.APIResource
+}
+
+func (f resourceTypesFilter) matches(groupVersion schema.GroupVersion, resource metav1.APIResource) bool {
+	if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+		return false // subresources and types that cannot be listed are not searchable
+	}
+	if f.namespacedOnly && !resource.Namespaced {
+		return false
+	}
+	return f.apiGroup == nil || *f.apiGroup == groupVersion.Group
+}
+
+// printResourceTypes prints the preferred version of every searchable resource type served by the cluster,
+// similar to `kubectl api-resources`.
+func printResourceTypes(
+	client discovery.DiscoveryInterface,
+	filter resourceTypesFilter,
+	out, errOut io.Writer,
+) error {
+	lists, err := discovery.ServerPreferredResources(client)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return fmt.Errorf("unable to discover resource types: %w", err)
+		}
+		// resources from groups that could be discovered are still listed
+		fmt.Fprintf(errOut, "Warning: %v\n", err)
+	}
+
+	var types []resourceType
+	for _, list := range lists {
+		groupVersion, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			return fmt.Errorf("invalid group version %q: %w", list.GroupVersion, parseErr)
+		}
+		for _, resource := range list.APIResources {
+			if filter.matches(groupVersion, resource) {
+				types = append(types, resourceType{groupVersion: groupVersion, resource: resource})
+			}
+		}
+	}
+	if len(types) == 0 {
+		return errors.New("no resource types found")
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].resource.Name != types[j].resource.Name {
+			return types[i].resource.Name < types[j].resource.Name
+		}
+		return types[i].groupVersion.Group < types[j].groupVersion.Group
+	})
+
+	data := make([][]string, 0, len(types))
+	for _, t := range types {
+		data = append(data, []string{
+			t.resource.Name,
+			strings.Join(t.resource.ShortNames, ","),
+			t.groupVersion.String(),
+			strconv.FormatBool(t.resource.Namespaced),
+			t.resource.Kind,
+		})
+	}
+	return printers.RenderTable(out, []string{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND"}, data)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPrintResourceTypes(t *testing.T) {
+	listVerbs := metav1.Verbs{"get", "list", "watch"}
+	client := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods", ShortNames: []string{"po"}, Namespaced: true, Kind: "Pod", Verbs: listVerbs},
+						{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: metav1.Verbs{"get"}},
+						{Name: "nodes", ShortNames: []string{"no"}, Kind: "Node", Verbs: listVerbs},
+						{Name: "bindings", Namespaced: true, Kind: "Binding", Verbs: metav1.Verbs{"create"}},
+					},
+				},
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", ShortNames: []string{"deploy"}, Namespaced: true, Kind: "Deployment", Verbs: listVerbs},
+					},
+				},
+			},
+		},
+	}
+	coreGroup, otherGroup := "", "example.com"
+
+	tests := []struct {
+		name    string
+		filter  resourceTypesFilter
+		want    [][]string
+		wantErr string
+	}{
+		{
+			name: "all searchable types",
+			want: [][]string{
+				{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND"},
+				{"deployments", "deploy", "apps/v1", "true", "Deployment"},
+				{"nodes", "no", "v1", "false", "Node"},
+				{"pods", "po", "v1", "true", "Pod"},
+			},
+		},
+		{
+			name:   "namespaced core types",
+			filter: resourceTypesFilter{namespacedOnly: true, apiGroup: &coreGroup},
+			want: [][]string{
+				{"NAME", "SHORTNAMES", "APIVERSION", "NAMESPACED", "KIND"},
+				{"pods", "po", "v1", "true", "Pod"},
+			},
+		},
+		{
+			name:    "unknown group",
+			filter:  resourceTypesFilter{apiGroup: &otherGroup},
+			wantErr: "no resource types found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := printResourceTypes(client, tt.filter, out, &bytes.Buffer{})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			got := make([][]string, 0, len(lines))
+			for _, line := range lines {
+				got = append(got, strings.Fields(line))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
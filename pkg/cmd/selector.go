@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// parseTypeName parses a TYPE/NAME flag value, as given to --selector-from, --older-than and --newer-than.
+func parseTypeName(value string) (string, string, error) {
+	resourceType, name, found := strings.Cut(value, "/")
+	if !found || resourceType == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("expected TYPE/NAME, e.g. pod/web-1, but got %q", value)
+	}
+	return resourceType, name, nil
+}
+
+// getObject gets the named object, from the namespace if the resource is namespaced.
+func getObject(
+	ctx context.Context,
+	client dynamic.Interface,
+	resource handlers.Resource,
+	namespace, name string,
+) (*unstructured.Unstructured, error) {
+	resources := client.Resource(resource.GroupVersionResource)
+	getter := dynamic.ResourceInterface(resources)
+	if resource.IsNamespaced {
+		getter = resources.Namespace(namespace)
+	}
+	obj, err := getter.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", resource.SingularName, name, err)
+	}
+	return obj, nil
+}
+
+// selectorFrom builds a label selector matching all labels of the named object.
+func selectorFrom(
+	ctx context.Context,
+	client dynamic.Interface,
+	resource handlers.Resource,
+	namespace, name string,
+) (string, error) {
+	obj, err := getObject(ctx, client, resource, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	objLabels := obj.GetLabels()
+	if len(objLabels) == 0 {
+		return "", fmt.Errorf("%s %s has no labels to build a selector from", resource.SingularName, name)
+	}
+	return labels.SelectorFromSet(objLabels).String(), nil
+}
+
+// resolveSelectorFrom replaces the label selector with the labels of the object given by --selector-from.
+func (o *FindOptions) resolveSelectorFrom(ctx context.Context) error {
+	if o.labelSelector != "" {
+		return errors.New("cannot specify both --selector and --selector-from flags")
+	}
+	if len(o.targetContexts) > 0 {
+		return errors.New("--selector-from flag cannot be combined with --contexts or --all-contexts flags")
+	}
+	searchType, name, err := parseTypeName(o.selectorFrom)
+	if err != nil {
+		return fmt.Errorf("invalid --selector-from flag value: %w", err)
+	}
+	resource, err := findResource(o.rest, searchType)
+	if err != nil {
+		return fmt.Errorf("unable to find resource type %q: %w", searchType, err)
+	}
+	if resource.IsNamespaced && o.allNamespaces {
+		return fmt.Errorf("--selector-from flag needs a namespace to get %s %s, it cannot be combined with --all-namespaces",
+			resource.SingularName, name)
+	}
+	client, err := dynamic.NewForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create dynamic client: %w", err)
+	}
+	o.labelSelector, err = selectorFrom(ctx, client, resource, o.userSpecifiedNamespace, name)
+	return err
+}
+
+// serviceSelector builds a label selector matching the pods targeted by the service.
+func serviceSelector(ctx context.Context, clientSet kubernetes.Interface, namespace, name string) (string, error) {
+	service, err := clientSet.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %s: %w", name, err)
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", fmt.Errorf("service %s has no selector, its endpoints are not backed by selected pods", name)
+	}
+	return labels.SelectorFromSet(service.Spec.Selector).String(), nil
+}
+
+// resolveForService replaces the label selector with the selector of the service given by --for-service.
+func (o *FindOptions) resolveForService(ctx context.Context) error {
+	if o.resourceType.GroupVersionResource != handlers.PodType {
+		return fmt.Errorf("--for-service flag is only supported for pods, but got %q",
+			o.resourceType.GroupVersionResource.String())
+	}
+	if o.labelSelector != "" || o.selectorFrom != "" {
+		return errors.New("cannot specify --for-service with --selector or --selector-from flags")
+	}
+	if len(o.targetContexts) > 0 {
+		return errors.New("--for-service flag cannot be combined with --contexts or --all-contexts flags")
+	}
+	if o.allNamespaces {
+		return errors.New("--for-service flag needs the namespace of the service, it cannot be combined with --all-namespaces")
+	}
+	clientSet, err := kubernetes.NewForConfig(o.rest)
+	if err != nil {
+		return fmt.Errorf("unable to create kubernetes client: %w", err)
+	}
+	o.labelSelector, err = serviceSelector(ctx, clientSet, o.userSpecifiedNamespace, o.forService)
+	return err
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/alikhil/kubectl-find/pkg/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseTypeName(t *testing.T) {
+	resourceType, name, err := parseTypeName("deployments.apps/web")
+	require.NoError(t, err)
+	assert.Equal(t, "deployments.apps", resourceType)
+	assert.Equal(t, "web", name)
+
+	for _, value := range []string{"web-1", "pod/", "/web-1", "pod/web/1"} {
+		_, _, err = parseTypeName(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestSelectorFrom(t *testing.T) {
+	podType := handlers.Resource{
+		GroupVersionResource: handlers.PodType,
+		SingularName:         "pod",
+		PluralName:           "pods",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "shop",
+			Labels:    map[string]string{"app": "web", "tier": "frontend"},
+		}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "shop"}},
+	)
+
+	selector, err := selectorFrom(t.Context(), client, podType, "shop", "web-1")
+	require.NoError(t, err)
+	assert.Equal(t, "app=web,tier=frontend", selector)
+
+	_, err = selectorFrom(t.Context(), client, podType, "shop", "debug")
+	require.EqualError(t, err, "pod debug has no labels to build a selector from")
+
+	_, err = selectorFrom(t.Context(), client, podType, "default", "web-1")
+	require.EqualError(t, err, `failed to get pod web-1: pods "web-1" not found`)
+}
+
+func TestServiceSelector(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web", "tier": "frontend"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external-db", Namespace: "shop"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+	)
+
+	selector, err := serviceSelector(t.Context(), clientSet, "shop", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "app=web,tier=frontend", selector)
+
+	_, err = serviceSelector(t.Context(), clientSet, "shop", "external-db")
+	require.EqualError(t, err, "service external-db has no selector, its endpoints are not backed by selected pods")
+
+	_, err = serviceSelector(t.Context(), clientSet, "default", "web")
+	require.EqualError(t, err, `failed to get service web: services "web" not found`)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// summaryPageSize is the page size of list calls counting objects for --summary.
+// The API server reports the number of remaining items with the first page, so usually one page is enough.
+const summaryPageSize = 500
+
+// typeCount is the number of objects of a resource type, or the error of counting them.
+type typeCount struct {
+	name  string // resource name, qualified with the group when several groups serve the same name
+	count int
+	err   error
+}
+
+// printNamespaceSummary prints how many objects of every namespaced resource type are in the namespace,
+// or in all namespaces if namespace is empty. At most limit types are counted at the same time.
+// Types that cannot be listed are skipped with a warning, types without objects are not printed.
+func printNamespaceSummary(
+	ctx context.Context,
+	discoveryClient discovery.DiscoveryInterface,
+	client dynamic.Interface,
+	namespace string,
+	limit int,
+	out, errOut io.Writer,
+) error {
+	lists, err := discovery.ServerPreferredResources(discoveryClient)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return fmt.Errorf("unable to discover resource types: %w", err)
+		}
+		fmt.Fprintf(errOut, "Warning: %v\n", err)
+	}
+
+	filter := resourceTypesFilter{namespacedOnly: true}
+	var resources []schema.GroupVersionResource
+	for _, list := range lists {
+		groupVersion, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			return fmt.Errorf("invalid group version %q: %w", list.GroupVersion, parseErr)
+		}
+		for _, resource := range list.APIResources {
+			if filter.matches(groupVersion, resource) {
+				resources = append(resources, groupVersion.WithResource(resource.Name))
+			}
+		}
+	}
+
+	groups := map[string]int{}
+	for _, resource := range resources {
+		groups[resource.Resource]++
+	}
+	counts := make([]typeCount, len(resources))
+	runConcurrently(len(resources), limit, func(i int) {
+		counts[i].name = resources[i].Resource
+		if groups[resources[i].Resource] > 1 {
+			counts[i].name = resources[i].GroupResource().String()
+		}
+		counts[i].count, counts[i].err = countObjects(ctx, client.Resource(resources[i]).Namespace(namespace))
+	})
+
+	var forbidden []string
+	data := make([][]string, 0, len(counts))
+	for _, count := range counts {
+		switch {
+		case apierrors.IsForbidden(count.err):
+			forbidden = append(forbidden, count.name)
+		case count.err != nil:
+			fmt.Fprintf(errOut, "Warning: skipping %s: %v\n", count.name, count.err)
+		case count.count > 0:
+			data = append(data, []string{count.name, strconv.Itoa(count.count)})
+		}
+	}
+	if len(forbidden) > 0 {
+		sort.Strings(forbidden)
+		fmt.Fprintf(errOut, "Warning: skipping resource types you are not allowed to list: %s\n", strings.Join(forbidden, ", "))
+	}
+	if len(data) == 0 {
+		_, err = fmt.Fprintln(errOut, "No resources found")
+		return err
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i][0] < data[j][0]
+	})
+	return printers.RenderTable(out, []string{"RESOURCE", "COUNT"}, data)
+}
+
+// countObjects returns the number of objects of a resource type, listing it page by page
+// until the API server reports the number of remaining items.
+func countObjects(ctx context.Context, resources dynamic.ResourceInterface) (int, error) {
+	count := 0
+	continueToken := ""
+	for {
+		list, err := resources.List(ctx, metav1.ListOptions{Limit: summaryPageSize, Continue: continueToken})
+		if err != nil {
+			return 0, err
+		}
+		count += len(list.Items)
+		if remaining := list.GetRemainingItemCount(); remaining != nil {
+			return count + int(*remaining), nil
+		}
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return count, nil
+		}
+	}
+}
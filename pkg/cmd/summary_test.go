@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPrintNamespaceSummary(t *testing.T) {
+	listVerbs := metav1.Verbs{"get", "list", "watch"}
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: listVerbs},
+						{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: listVerbs},
+						{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: listVerbs},
+						{Name: "services", Namespaced: true, Kind: "Service", Verbs: listVerbs},
+						{Name: "nodes", Kind: "Node", Verbs: listVerbs},
+					},
+				},
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: listVerbs},
+					},
+				},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	inNamespace := func(name, namespace string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace}
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.Pod{ObjectMeta: inNamespace("web-1", "shop")},
+		&v1.Pod{ObjectMeta: inNamespace("web-2", "shop")},
+		&v1.Pod{ObjectMeta: inNamespace("api-1", "billing")},
+		&v1.ConfigMap{ObjectMeta: inNamespace("web-config", "shop")},
+		&v1.Secret{ObjectMeta: inNamespace("web-tls", "shop")},
+		&v1.Service{ObjectMeta: inNamespace("api", "billing")},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&appsv1.Deployment{ObjectMeta: inNamespace("web", "shop")},
+	)
+	client.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("secrets"), "", nil)
+	})
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	require.NoError(t, printNamespaceSummary(t.Context(), discoveryClient, client, "shop", 2, out, errOut))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	rows := make([][]string, len(lines))
+	for i, line := range lines {
+		rows[i] = strings.Fields(line)
+	}
+	assert.Equal(t, [][]string{
+		{"RESOURCE", "COUNT"},
+		{"configmaps", "1"},
+		{"deployments", "1"},
+		{"pods", "2"},
+	}, rows, "types without objects in the namespace and cluster-scoped types are not printed")
+	assert.Equal(t, "Warning: skipping resource types you are not allowed to list: secrets\n", errOut.String())
+
+	out.Reset()
+	errOut.Reset()
+	require.NoError(t, printNamespaceSummary(t.Context(), discoveryClient, client, "empty", 2, out, errOut))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "No resources found\n")
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/discovery"
+)
+
+// maxSupportedSkew is the number of minor versions the client libraries may differ from the server,
+// following the kubectl version skew policy.
+const maxSupportedSkew = 1
+
+const clientGoModule = "k8s.io/client-go"
+
+// BuildInfo describes the plugin build, set at link time by goreleaser.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// VersionOptions provides information required to print the plugin and server versions.
+type VersionOptions struct {
+	configFlags   *genericclioptions.ConfigFlags
+	build         BuildInfo
+	clientVersion *version.Version // Kubernetes version of the client libraries, nil when unknown
+	check         bool
+
+	genericiooptions.IOStreams
+}
+
+// NewCmdVersion provides a cobra command printing the plugin version,
+// and with --check the version of the connected cluster.
+func NewCmdVersion(streams genericiooptions.IOStreams, build BuildInfo) *cobra.Command {
+	o := &VersionOptions{
+		configFlags:   genericclioptions.NewConfigFlags(true),
+		build:         build,
+		clientVersion: clientLibraryVersion(),
+		IOStreams:     streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "version",
+		Short:        "Print the version number of kubectl-find",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			var client discovery.ServerVersionInterface
+			if o.check {
+				var err error
+				if client, err = o.configFlags.ToDiscoveryClient(); err != nil {
+					fmt.Fprintf(o.ErrOut, "Warning: unable to connect to the cluster: %v\n", err)
+				}
+			}
+			return o.Run(client)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.check, "check", false,
+		"Also print the server version of the current cluster and warn about version skew.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Run prints the plugin version and, when client is set, the server version with a warning on skew.
+// Failing to reach the server is reported as a warning, so the plugin version is always printed.
+func (o *VersionOptions) Run(client discovery.ServerVersionInterface) error {
+	fmt.Fprintf(o.Out, "version: %s\ncommit: %s\ndate: %s\n", o.build.Version, o.build.Commit, o.build.Date)
+	if client == nil {
+		return nil
+	}
+
+	info, err := client.ServerVersion()
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Warning: unable to get server version: %v\n", err)
+		return nil
+	}
+	fmt.Fprintf(o.Out, "server version: %s\n", info.GitVersion)
+
+	serverVersion, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Warning: unable to parse server version %q: %v\n", info.GitVersion, err)
+		return nil
+	}
+	if o.clientVersion == nil {
+		return nil
+	}
+	if skew := minorVersionSkew(o.clientVersion, serverVersion); skew > maxSupportedSkew {
+		fmt.Fprintf(o.ErrOut,
+			"Warning: kubectl-find is built for Kubernetes %d.%d, which is %d minor versions away from the server %d.%d; "+
+				"some resources or fields may not work as expected\n",
+			o.clientVersion.Major(), o.clientVersion.Minor(), skew, serverVersion.Major(), serverVersion.Minor())
+	}
+	return nil
+}
+
+// clientLibraryVersion returns the Kubernetes version matching the client-go module the plugin is built with,
+// e.g. 1.34 for client-go v0.34.1, or nil when it is unknown.
+func clientLibraryVersion() *version.Version {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != clientGoModule {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		parsed, err := version.ParseSemantic(dep.Version)
+		if err != nil || parsed.Major() != 0 {
+			return nil
+		}
+		return version.MajorMinor(1, parsed.Minor())
+	}
+	return nil
+}
+
+// minorVersionSkew returns the number of minor versions between client and server.
+func minorVersionSkew(client, server *version.Version) uint {
+	if client.Minor() > server.Minor() {
+		return client.Minor() - server.Minor()
+	}
+	return server.Minor() - client.Minor()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	apimachineryversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestVersionOptions_Run(t *testing.T) {
+	build := BuildInfo{Version: "v1.2.3", Commit: "abc", Date: "2026-01-01"}
+	buildOut := "version: v1.2.3\ncommit: abc\ndate: 2026-01-01\n"
+
+	tests := []struct {
+		name          string
+		serverVersion string
+		serverErr     error
+		noCheck       bool
+		wantOut       string
+		wantErrOut    string
+	}{
+		{
+			name:    "without check",
+			noCheck: true,
+			wantOut: buildOut,
+		},
+		{
+			name:          "supported skew",
+			serverVersion: "v1.33.2-gke.100",
+			wantOut:       buildOut + "server version: v1.33.2-gke.100\n",
+		},
+		{
+			name:          "large skew",
+			serverVersion: "v1.30.0",
+			wantOut:       buildOut + "server version: v1.30.0\n",
+			wantErrOut: "Warning: kubectl-find is built for Kubernetes 1.34, which is 4 minor versions away from the server 1.30; " +
+				"some resources or fields may not work as expected\n",
+		},
+		{
+			name:       "unreachable server",
+			serverErr:  errors.New("connection refused"),
+			wantOut:    buildOut,
+			wantErrOut: "Warning: unable to get server version: connection refused\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			o := &VersionOptions{build: build, clientVersion: version.MajorMinor(1, 34), IOStreams: streams}
+
+			client := &fakediscovery.FakeDiscovery{
+				Fake:               &k8stesting.Fake{},
+				FakedServerVersion: &apimachineryversion.Info{GitVersion: tt.serverVersion},
+			}
+			if tt.serverErr != nil {
+				client.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.serverErr
+				})
+			}
+
+			var err error
+			if tt.noCheck {
+				err = o.Run(nil)
+			} else {
+				err = o.Run(client)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, out.String())
+			assert.Equal(t, tt.wantErrOut, errOut.String())
+		})
+	}
+}
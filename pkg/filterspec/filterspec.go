@@ -0,0 +1,71 @@
+// Package filterspec defines the YAML schema of filter files loaded with --filter-file.
+package filterspec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FilterSpec is a set of filters saved in a file, so standard queries can be version-controlled.
+// Fields mirror the command line flags of the same name; unset fields leave the flags untouched.
+type FilterSpec struct {
+	Type           string   `json:"type,omitempty"`           // resource type, used when none is given as an argument
+	Name           string   `json:"name,omitempty"`           // --name, regular expression
+	NameExclude    []string `json:"nameExclude,omitempty"`    // --name-exclude
+	Status         string   `json:"status,omitempty"`         // --status
+	MinAge         string   `json:"minAge,omitempty"`         // --min-age
+	MaxAge         string   `json:"maxAge,omitempty"`         // --max-age
+	Selector       string   `json:"selector,omitempty"`       // --selector
+	FieldSelector  string   `json:"fieldSelector,omitempty"`  // --field-selector
+	Node           string   `json:"node,omitempty"`           // --node, regular expression
+	Image          string   `json:"image,omitempty"`          // --image, regular expression
+	ContainerName  string   `json:"containerName,omitempty"`  // --container-name
+	Env            []string `json:"env,omitempty"`            // --env
+	NodeConditions []string `json:"nodeConditions,omitempty"` // --node-condition
+	Conditions     []string `json:"conditions,omitempty"`     // --condition
+	ConditionAges  []string `json:"conditionAges,omitempty"`  // --condition-age
+	LabelCompare   []string `json:"labelCompare,omitempty"`   // --label-compare
+	Restarted      bool     `json:"restarted,omitempty"`      // --restarted
+	MinRestarts    int32    `json:"minRestarts,omitempty"`    // --min-restarts
+	RestartReason  string   `json:"restartReason,omitempty"`  // --restart-reason
+	JQ             string   `json:"jq,omitempty"`             // --jq
+
+	// Flags holds the other filter flags by name, e.g. namespace or for-service.
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// Parse parses a filter file, rejecting unknown keys so typos do not silently widen the query.
+func Parse(data []byte) (*FilterSpec, error) {
+	spec := &FilterSpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid filter file: %w", err)
+	}
+	return spec, nil
+}
+
+// Load reads and parses the filter file at path.
+func Load(path string) (*FilterSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filter file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Write saves the spec as YAML to path, creating the parent directories.
+func (s *FilterSpec) Write(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal filters: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for filter file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write filter file: %w", err)
+	}
+	return nil
+}
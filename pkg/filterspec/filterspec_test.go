@@ -0,0 +1,56 @@
+package filterspec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+	spec := &FilterSpec{
+		Name:           "^web-",
+		NameExclude:    []string{"canary$"},
+		Status:         "Running",
+		MinAge:         "1h",
+		MaxAge:         "168h",
+		Selector:       "app=web,tier!=cache",
+		Node:           "^worker-",
+		Image:          "nginx",
+		ContainerName:  "istio-proxy",
+		Env:            []string{"DEBUG=true"},
+		NodeConditions: []string{"Ready=True"},
+		Restarted:      true,
+		MinRestarts:    5,
+		JQ:             ".spec.nodeSelector == null",
+	}
+
+	data, err := yaml.Marshal(spec)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, spec, loaded)
+}
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte("status: Failed\nminAge: 24h\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &FilterSpec{Status: "Failed", MinAge: "24h"}, spec)
+
+	_, err = Parse([]byte("status: Failed\nminimumAge: 24h\n"))
+	require.ErrorContains(t, err, `unknown field "minimumAge"`)
+
+	_, err = Parse([]byte("minRestarts: many\n"))
+	require.ErrorContains(t, err, "invalid filter file")
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "failed to read filter file")
+}
@@ -0,0 +1,36 @@
+package filterspec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var queryNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// QueriesDir returns the directory of saved queries, $XDG_CONFIG_HOME/kubectl-find/queries
+// or ~/.config/kubectl-find/queries when XDG_CONFIG_HOME is not set.
+func QueriesDir() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to find home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "kubectl-find", "queries"), nil
+}
+
+// QueryPath returns the path of the file the named query is saved to.
+func QueryPath(name string) (string, error) {
+	if !queryNameRegex.MatchString(name) {
+		return "", fmt.Errorf("invalid query name %q, must consist of letters, digits, '.', '_' or '-'", name)
+	}
+	dir, err := QueriesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
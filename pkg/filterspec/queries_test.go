@@ -0,0 +1,27 @@
+package filterspec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/etc/xdg")
+	path, err := QueryPath("failed-pods")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/etc/xdg", "kubectl-find", "queries", "failed-pods.yaml"), path)
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/dev")
+	path, err = QueryPath("failed-pods")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/dev", ".config", "kubectl-find", "queries", "failed-pods.yaml"), path)
+
+	for _, name := range []string{"", "../secrets", "prod/failed", ".hidden"} {
+		_, err = QueryPath(name)
+		assert.ErrorContains(t, err, "invalid query name", name)
+	}
+}
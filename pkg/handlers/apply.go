@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// patchTypes maps values of the --patch-type flag to API patch types.
+//
+//nolint:gochecknoglobals
+var patchTypes = map[string]k8s_types.PatchType{
+	"strategic": k8s_types.StrategicMergePatchType,
+	"merge":     k8s_types.MergePatchType,
+	"json":      k8s_types.JSONPatchType,
+	"apply":     k8s_types.ApplyPatchType,
+}
+
+// ParsePatchType converts the --patch-type flag value to an API patch type.
+func ParsePatchType(value string) (k8s_types.PatchType, error) {
+	if patchType, ok := patchTypes[value]; ok {
+		return patchType, nil
+	}
+	names := make([]string, 0, len(patchTypes))
+	for name := range patchTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown patch type %q, must be one of: %s", value, strings.Join(names, ", "))
+}
+
+// patchOptions returns the options for patch requests; server-side apply requires a field manager.
+func patchOptions(options ActionOptions) metav1.PatchOptions {
+	if options.PatchStrategy != k8s_types.ApplyPatchType {
+		return metav1.PatchOptions{}
+	}
+	force := options.ForceConflicts
+	return metav1.PatchOptions{FieldManager: applyFieldManager, Force: &force}
+}
+
+// renderPatch returns the patch for the object: --patch as is,
+// or the output of --patch-template executed against the object content.
+func renderPatch(options ActionOptions, content map[string]interface{}) ([]byte, error) {
+	if options.PatchTemplate == nil {
+		return []byte(options.Patch), nil
+	}
+	var buf bytes.Buffer
+	if err := options.PatchTemplate.Execute(&buf, content); err != nil {
+		return nil, fmt.Errorf("failed to render patch template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// checkJSONPatch applies a JSON patch to the object in memory, so that a patch removing a missing field
+// or an array index out of range is rejected before any matched object is patched.
+// Other patch types are not checked, as they do not fail on missing fields.
+func checkJSONPatch(options ActionOptions, patch []byte, obj interface{}) error {
+	if options.PatchStrategy != k8s_types.JSONPatchType {
+		return nil
+	}
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+	if _, err = applyPatchLocally(original, patch, k8s_types.JSONPatchType, nil); err != nil {
+		return fmt.Errorf("json patch does not apply: %w", err)
+	}
+	return nil
+}
+
+// patchBody returns the patch to send for the object. The same apply patch may be sent to every matched object,
+// so apiVersion, kind, name and namespace required by server-side apply are filled in from the object.
+func patchBody(options ActionOptions, patch []byte, obj metav1.Object, gvk schema.GroupVersionKind) ([]byte, error) {
+	if options.PatchStrategy != k8s_types.ApplyPatchType {
+		return patch, nil
+	}
+	body := map[string]interface{}{}
+	if err := yaml.Unmarshal(patch, &body); err != nil {
+		return nil, fmt.Errorf("invalid apply patch: %w", err)
+	}
+	applied := unstructured.Unstructured{Object: body}
+	applied.SetGroupVersionKind(gvk)
+	applied.SetName(obj.GetName())
+	if obj.GetNamespace() != "" {
+		applied.SetNamespace(obj.GetNamespace())
+	}
+	return applied.MarshalJSON()
+}
+
+// applyConflicts returns the conflicting fields reported by the API server
+// when err is a server-side apply conflict with other field managers.
+func applyConflicts(options ActionOptions, err error) ([]string, bool) {
+	if options.PatchStrategy != k8s_types.ApplyPatchType || !apierrors.IsConflict(err) {
+		return nil, false
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return nil, false
+	}
+	var conflicts []string
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type != metav1.CauseTypeFieldManagerConflict {
+				continue
+			}
+			if cause.Field != "" {
+				conflicts = append(conflicts, cause.Message+": "+cause.Field)
+			} else {
+				conflicts = append(conflicts, cause.Message)
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		conflicts = append(conflicts, status.Status().Message)
+	}
+	return conflicts, true
+}
+
+// printApplyConflicts reports fields of the object that are owned by other field managers.
+func printApplyConflicts(out io.Writer, name string, conflicts []string) {
+	fmt.Fprintf(out, "Conflict: %s was not patched, fields are managed by other field managers:\n", name)
+	for _, conflict := range conflicts {
+		fmt.Fprintf(out, "  - %s\n", conflict)
+	}
+}
+
+// applyConflictsError summarizes objects that were skipped because of field manager conflicts.
+func applyConflictsError(conflicted, total int, plural string) error {
+	return fmt.Errorf("%d of %d %s were not patched because of field manager conflicts, "+
+		"use --force-conflicts to take ownership of the conflicting fields", conflicted, total, plural)
+}
+
+// patchChanged returns true if the patch changes the object, applying it locally to the object as it was matched,
+// so changes made by others between listing and patching, e.g. to the status, are not reported.
+// The server keeps the resource version of objects a patch leaves as they are, but it changes with such drift too.
+// Server-side apply patches cannot be applied locally, the fields they set are compared with the patched object.
+func patchChanged(
+	patchType k8s_types.PatchType,
+	patch []byte,
+	before, after map[string]interface{},
+	dataStruct interface{},
+) (bool, error) {
+	if patchType == k8s_types.ApplyPatchType {
+		applied := map[string]interface{}{}
+		if err := json.Unmarshal(patch, &applied); err != nil {
+			return false, fmt.Errorf("invalid apply patch: %w", err)
+		}
+		return fieldsChanged(applied, before, after), nil
+	}
+
+	original, err := json.Marshal(before)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode object: %w", err)
+	}
+	patched, err := applyPatchLocally(original, patch, patchType, dataStruct)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply patch: %w", err)
+	}
+	// both are decoded from JSON, so numbers have the same type
+	var originalContent, patchedContent map[string]interface{}
+	if err = json.Unmarshal(original, &originalContent); err != nil {
+		return false, fmt.Errorf("failed to decode object: %w", err)
+	}
+	if err = json.Unmarshal(patched, &patchedContent); err != nil {
+		return false, fmt.Errorf("failed to decode patched object: %w", err)
+	}
+	return !equality.Semantic.DeepEqual(withoutServerFields(originalContent), withoutServerFields(patchedContent)), nil
+}
+
+// fieldsChanged returns true if any field set in fields differs between before and after.
+// Lists are compared as a whole.
+func fieldsChanged(fields, before, after map[string]interface{}) bool {
+	for key, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok {
+			beforeNested, _ := before[key].(map[string]interface{})
+			afterNested, _ := after[key].(map[string]interface{})
+			if fieldsChanged(nested, beforeNested, afterNested) {
+				return true
+			}
+			continue
+		}
+		if !equality.Semantic.DeepEqual(before[key], after[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutServerFields returns a shallow copy of the object content without fields that are not part of its state.
+func withoutServerFields(content map[string]interface{}) map[string]interface{} {
+	trimmed := make(map[string]interface{}, len(content))
+	for key, value := range content {
+		trimmed[key] = value
+	}
+	delete(trimmed, "apiVersion")
+	delete(trimmed, "kind")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		trimmedMetadata := make(map[string]interface{}, len(metadata))
+		for key, value := range metadata {
+			trimmedMetadata[key] = value
+		}
+		delete(trimmedMetadata, "resourceVersion")
+		delete(trimmedMetadata, "managedFields")
+		delete(trimmedMetadata, "generation")
+		trimmed["metadata"] = trimmedMetadata
+	}
+	return trimmed
+}
+
+// patchedSuffix marks objects the patch left unchanged in the "Patched ..." lines.
+func patchedSuffix(changed bool) string {
+	if changed {
+		return ""
+	}
+	return " (no change)"
+}
+
+// printPatchSummary reports how many of the patched objects actually changed,
+// the others were already in the target state.
+func printPatchSummary(out io.Writer, changed, patched int, plural string) error {
+	_, err := fmt.Fprintf(out, "%d of %d %s changed, %d already in the target state\n",
+		changed, patched, plural, patched-changed)
+	return err
+}
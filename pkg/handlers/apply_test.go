@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestParsePatchType(t *testing.T) {
+	patchType, err := ParsePatchType("apply")
+	require.NoError(t, err)
+	assert.Equal(t, k8s_types.ApplyPatchType, patchType)
+
+	_, err = ParsePatchType("replace")
+	require.EqualError(t, err, `unknown patch type "replace", must be one of: apply, json, merge, strategic`)
+}
+
+func TestPatchBody_Apply(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetName("web")
+	obj.SetNamespace("prod")
+
+	options := ActionOptions{Patch: "spec:\n  replicas: 2\n", PatchStrategy: k8s_types.ApplyPatchType}
+	body, err := patchBody(options, []byte(options.Patch), obj, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {"name": "web", "namespace": "prod"},
+		"spec": {"replicas": 2}
+	}`, string(body))
+
+	options.PatchStrategy = k8s_types.MergePatchType
+	body, err = patchBody(options, []byte(options.Patch), obj, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	require.NoError(t, err)
+	assert.Equal(t, options.Patch, string(body))
+}
+
+func TestUniversalHandler_ApplyPatchConflicts(t *testing.T) {
+	deployments := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	}
+	deploymentType := Resource{
+		GroupVersionResource: appsv1.SchemeGroupVersion.WithResource("deployments"),
+		GroupVersionKind:     appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		SingularName:         "deployment",
+		PluralName:           "deployments",
+		IsNamespaced:         true,
+	}
+
+	tests := []struct {
+		name           string
+		forceConflicts bool
+		wantErr        string
+		wantOut        string
+		wantErrOut     string
+	}{
+		{
+			name: "conflicts are reported per object",
+			wantErr: "1 of 2 deployments were not patched because of field manager conflicts, " +
+				"use --force-conflicts to take ownership of the conflicting fields",
+			wantOut: "Patched deployment api\n1 of 1 deployments changed, 0 already in the target state\n",
+			wantErrOut: "Conflict: deployment web was not patched, fields are managed by other field managers:\n" +
+				"  - conflict with \"kubectl-client-side-apply\" using apps/v1: .spec.replicas\n",
+		},
+		{
+			name:           "force conflicts",
+			forceConflicts: true,
+			wantOut:        "Patched deployment api\nPatched deployment web\n2 of 2 deployments changed, 0 already in the target state\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, appsv1.AddToScheme(scheme))
+			client := dynamicfake.NewSimpleDynamicClient(scheme, deployments...)
+			client.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				patch := action.(k8stesting.PatchActionImpl)
+				assert.Equal(t, k8s_types.ApplyPatchType, patch.GetPatchType())
+				assert.Equal(t, applyFieldManager, patch.PatchOptions.FieldManager)
+
+				force := patch.PatchOptions.Force
+				if patch.GetName() == "web" && (force == nil || !*force) {
+					return true, nil, apierrors.NewApplyConflict([]metav1.StatusCause{{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+						Field:   ".spec.replicas",
+					}}, "Apply failed with 1 conflict")
+				}
+				return true, nil, nil
+			})
+
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			handler := UniversalHandler{
+				opts: UniversalHandlerOptions{Client: client, Resource: deploymentType},
+			}
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:      "default",
+				Action:         ActionPatch,
+				Patch:          `{"spec": {"replicas": 2}}`,
+				PatchStrategy:  k8s_types.ApplyPatchType,
+				ForceConflicts: tt.forceConflicts,
+				SkipConfirm:    true,
+				ResourceType:   deploymentType,
+				Streams:        &streams,
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantOut, out.String())
+			assert.Equal(t, tt.wantErrOut, errOut.String())
+		})
+	}
+}
+
+func TestRenderPatch(t *testing.T) {
+	content := map[string]interface{}{"metadata": map[string]interface{}{"name": "web"}}
+
+	body, err := renderPatch(ActionOptions{Patch: `{"spec": {}}`}, content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec": {}}`, string(body))
+
+	tmpl := template.Must(template.New("patch").Option("missingkey=error").
+		Parse(`{"metadata": {"labels": {"app": "{{ .metadata.name }}"}}}`))
+	body, err = renderPatch(ActionOptions{PatchTemplate: tmpl}, content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"metadata": {"labels": {"app": "web"}}}`, string(body))
+
+	tmpl = template.Must(template.New("patch").Option("missingkey=error").Parse(`{{ .spec.replicas }}`))
+	_, err = renderPatch(ActionOptions{PatchTemplate: tmpl}, content)
+	require.ErrorContains(t, err, "failed to render patch template")
+}
+
+func TestUniversalHandler_PatchTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	deploymentType := Resource{
+		GroupVersionResource: appsv1.SchemeGroupVersion.WithResource("deployments"),
+		GroupVersionKind:     appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		SingularName:         "deployment",
+		PluralName:           "deployments",
+		IsNamespaced:         true,
+	}
+	patches := map[string]string{}
+	client.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchActionImpl)
+		patches[patch.GetName()] = string(patch.GetPatch())
+		return true, nil, nil
+	})
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	handler := UniversalHandler{
+		opts: UniversalHandlerOptions{Client: client, Resource: deploymentType},
+	}
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionPatch,
+		PatchTemplate: template.Must(template.New("patch").
+			Parse(`{"metadata": {"annotations": {"owner": "{{ .metadata.name }}-team"}}}`)),
+		SkipConfirm:  true,
+		ResourceType: deploymentType,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"api": `{"metadata": {"annotations": {"owner": "api-team"}}}`,
+		"web": `{"metadata": {"annotations": {"owner": "web-team"}}}`,
+	}, patches)
+	assert.Equal(t, "Patched deployment api\nPatched deployment web\n2 of 2 deployments changed, 0 already in the target state\n", out.String())
+}
+
+func TestPodHandler_PatchTemplate(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+	)
+	patches := map[string]string{}
+	clientSet.PrependReactor("patch", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchActionImpl)
+		patches[patch.GetName()] = string(patch.GetPatch())
+		return true, nil, nil
+	})
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	handler := &PodHandler{clientSet: clientSet}
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionPatch,
+		PatchTemplate: template.Must(template.New("patch").
+			Parse(`{"metadata": {"labels": {"pod": "{{ .metadata.name }}"}}}`)),
+		SkipConfirm: true,
+		Streams:     &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"web-1": `{"metadata": {"labels": {"pod": "web-1"}}}`,
+		"web-2": `{"metadata": {"labels": {"pod": "web-2"}}}`,
+	}, patches)
+	assert.Equal(t, "Patched pod web-1 in namespace default\nPatched pod web-2 in namespace default\n"+
+		"2 of 2 pods changed, 0 already in the target state\n", out.String())
+}
+
+func TestUniversalHandler_JSONPatchRemove(t *testing.T) {
+	configMap := func(name string, finalizers ...string) *v1.ConfigMap {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Finalizers: finalizers}}
+	}
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	removeSecondFinalizer := ActionOptions{
+		Namespace:     "default",
+		Action:        ActionPatch,
+		Patch:         `[{"op": "remove", "path": "/metadata/finalizers/1"}]`,
+		PatchStrategy: k8s_types.JSONPatchType,
+		SkipConfirm:   true,
+		ResourceType:  configMapType,
+	}
+	finalizers := func(t *testing.T, client *dynamicfake.FakeDynamicClient, name string) []string {
+		t.Helper()
+		obj, err := client.Resource(configMapType.GroupVersionResource).Namespace("default").
+			Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return obj.GetFinalizers()
+	}
+
+	t.Run("removes the array element from every object", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, v1.AddToScheme(scheme))
+		client := dynamicfake.NewSimpleDynamicClient(scheme,
+			configMap("api", "example.com/backup", "example.com/cleanup"),
+			configMap("web", "example.com/cleanup", "example.com/backup"),
+		)
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		options := removeSecondFinalizer
+		options.Streams = &streams
+
+		handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+		require.NoError(t, handler.HandleAction(t.Context(), options))
+		assert.Equal(t, "Patched configmap api\nPatched configmap web\n2 of 2 configmaps changed, 0 already in the target state\n", out.String())
+		assert.Equal(t, []string{"example.com/backup"}, finalizers(t, client, "api"))
+		assert.Equal(t, []string{"example.com/cleanup"}, finalizers(t, client, "web"))
+	})
+
+	t.Run("rejects a missing index before patching any object", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, v1.AddToScheme(scheme))
+		client := dynamicfake.NewSimpleDynamicClient(scheme,
+			configMap("api", "example.com/backup", "example.com/cleanup"),
+			configMap("web", "example.com/cleanup"),
+		)
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		options := removeSecondFinalizer
+		options.Streams = &streams
+
+		handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+		err := handler.HandleAction(t.Context(), options)
+		require.ErrorContains(t, err, "configmap web: json patch does not apply")
+		assert.Empty(t, out.String())
+		assert.Equal(t, []string{"example.com/backup", "example.com/cleanup"}, finalizers(t, client, "api"))
+	})
+}
+
+func TestPodHandler_JSONPatchRemove(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"debug": "true"}}},
+	)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	handler := &PodHandler{clientSet: clientSet}
+
+	options := ActionOptions{
+		Namespace:     "default",
+		Action:        ActionPatch,
+		Patch:         `[{"op": "remove", "path": "/metadata/labels/debug"}]`,
+		PatchStrategy: k8s_types.JSONPatchType,
+		SkipConfirm:   true,
+		Streams:       &streams,
+	}
+	require.NoError(t, handler.HandleAction(t.Context(), options))
+	pod, err := clientSet.CoreV1().Pods("default").Get(t.Context(), "web-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pod.Labels)
+
+	err = handler.HandleAction(t.Context(), options)
+	require.ErrorContains(t, err, "pod web-1: json patch does not apply")
+}
+
+func TestUniversalHandler_PatchReportsUnchanged(t *testing.T) {
+	configMap := func(name string, labels map[string]string) *v1.ConfigMap {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		configMap("api", map[string]string{"team": "payments"}),
+		configMap("web", map[string]string{"team": "frontend"}),
+	)
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:     "default",
+		Action:        ActionPatch,
+		Patch:         `{"metadata": {"labels": {"team": "payments"}}}`,
+		PatchStrategy: k8s_types.MergePatchType,
+		SkipConfirm:   true,
+		ResourceType:  configMapType,
+		Streams:       &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched configmap api (no change)\nPatched configmap web\n"+
+		"1 of 2 configmaps changed, 1 already in the target state\n", out.String())
+}
+
+func TestPodHandler_PatchReportsUnchanged(t *testing.T) {
+	pod := func(name, team string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"team": team},
+		}}
+	}
+	clientSet := fake.NewClientset(pod("web-1", "frontend"), pod("web-2", "payments"))
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	handler := &PodHandler{clientSet: clientSet}
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:     "default",
+		Action:        ActionPatch,
+		Patch:         `{"metadata": {"labels": {"team": "frontend"}}}`,
+		PatchStrategy: k8s_types.StrategicMergePatchType,
+		SkipConfirm:   true,
+		Streams:       &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched pod web-1 in namespace default (no change)\nPatched pod web-2 in namespace default\n"+
+		"1 of 2 pods changed, 1 already in the target state\n", out.String())
+}
+
+func TestPodHandler_PatchIgnoresStatusDrift(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			Labels:    map[string]string{"team": "frontend"},
+		},
+		Status: v1.PodStatus{Phase: v1.PodPending},
+	}
+	clientSet := fake.NewClientset(pod)
+	// the pod starts running after it was listed, before it is patched
+	clientSet.PrependReactor("patch", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		running := pod.DeepCopy()
+		running.Status.Phase = v1.PodRunning
+		return false, nil, clientSet.Tracker().Update(v1.SchemeGroupVersion.WithResource("pods"), running, "default")
+	})
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	handler := &PodHandler{clientSet: clientSet}
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:     "default",
+		Action:        ActionPatch,
+		Patch:         `{"metadata": {"labels": {"team": "frontend"}}}`,
+		PatchStrategy: k8s_types.StrategicMergePatchType,
+		SkipConfirm:   true,
+		Streams:       &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched pod web-1 in namespace default (no change)\n"+
+		"0 of 1 pods changed, 1 already in the target state\n", out.String())
+}
+
+func TestPatchChanged_Apply(t *testing.T) {
+	before := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"team": "frontend"}},
+		"status":   map[string]interface{}{"phase": "Pending"},
+	}
+	drifted := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"team": "frontend"}},
+		"status":   map[string]interface{}{"phase": "Running"},
+	}
+	unchanged := []byte(`{"metadata": {"name": "web", "labels": {"team": "frontend"}}}`)
+	relabeled := []byte(`{"metadata": {"name": "web", "labels": {"team": "payments"}}}`)
+
+	changed, err := patchChanged(k8s_types.ApplyPatchType, unchanged, before, drifted, nil)
+	require.NoError(t, err)
+	assert.False(t, changed, "fields the patch does not set are ignored")
+
+	relabeledAfter := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "labels": map[string]interface{}{"team": "payments"}},
+		"status":   map[string]interface{}{"phase": "Running"},
+	}
+	changed, err = patchChanged(k8s_types.ApplyPatchType, relabeled, before, relabeledAfter, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
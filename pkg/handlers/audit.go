@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Outcomes of the action on an audited object.
+const (
+	AuditOutcomeMatched   = "matched" // the object matched, but no action was performed on it
+	AuditOutcomeSucceeded = "succeeded"
+	AuditOutcomeFailed    = "failed"
+)
+
+// AuditItem is a matched object and the outcome of the action on it.
+type AuditItem struct {
+	Context   string    `json:"context,omitempty"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	UID       string    `json:"uid,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// AuditTrail records matched objects and the outcome of the action on each of them for --audit-log.
+type AuditTrail struct {
+	context string
+	log     *auditLog
+}
+
+// auditLog holds the items recorded by an audit trail and its per-context trails.
+type auditLog struct {
+	mu    sync.Mutex
+	items []AuditItem
+	index map[string]int // position of the item of an object in items, by context, kind, namespace and name
+	now   func() time.Time
+}
+
+// NewAuditTrail creates an empty audit trail recording objects of the given kubeconfig context.
+func NewAuditTrail(context string) *AuditTrail {
+	return &AuditTrail{context: context, log: &auditLog{index: map[string]int{}, now: time.Now}}
+}
+
+// ForContext returns a trail recording objects of another context into the same items,
+// used when several contexts are searched.
+func (a *AuditTrail) ForContext(context string) *AuditTrail {
+	if a == nil {
+		return nil
+	}
+	return &AuditTrail{context: context, log: a.log}
+}
+
+// Matched records that the object of the kind matched the filters.
+func (a *AuditTrail) Matched(kind string, obj metav1.Object) {
+	if a == nil {
+		return
+	}
+	a.log.mu.Lock()
+	defer a.log.mu.Unlock()
+	a.log.index[a.key(kind, obj)] = len(a.log.items)
+	a.log.items = append(a.log.items, AuditItem{
+		Context:   a.context,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       string(obj.GetUID()),
+		Outcome:   AuditOutcomeMatched,
+		Time:      a.log.now(),
+	})
+}
+
+// Done records the outcome of the action on a matched object, err is nil if the action succeeded.
+func (a *AuditTrail) Done(kind string, obj metav1.Object, err error) {
+	if a == nil {
+		return
+	}
+	a.log.mu.Lock()
+	defer a.log.mu.Unlock()
+	i, found := a.log.index[a.key(kind, obj)]
+	if !found {
+		return
+	}
+	item := &a.log.items[i]
+	item.Outcome, item.Time = AuditOutcomeSucceeded, a.log.now()
+	if err != nil {
+		item.Outcome, item.Error = AuditOutcomeFailed, err.Error()
+	}
+}
+
+// Items returns the recorded objects in the order they matched.
+func (a *AuditTrail) Items() []AuditItem {
+	a.log.mu.Lock()
+	defer a.log.mu.Unlock()
+	return append([]AuditItem{}, a.log.items...)
+}
+
+func (a *AuditTrail) key(kind string, obj metav1.Object) string {
+	return a.context + "/" + kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// auditMatched records the matched object in the audit trail, if any.
+func (o ActionOptions) auditMatched(obj metav1.Object) {
+	o.Audit.Matched(o.ResourceType.SingularName, obj)
+}
+
+// auditDone records the outcome of the action on the object in the audit trail, if any.
+func (o ActionOptions) auditDone(obj metav1.Object, err error) {
+	o.Audit.Done(o.ResourceType.SingularName, obj, err)
+}
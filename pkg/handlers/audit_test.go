@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestAuditTrail_ExecEach(t *testing.T) {
+	audit := NewAuditTrail("prod")
+	handler := newConfigMapHandler(t)
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:       ActionExecEach,
+		ExecEach:     mustParseCommand(t, "test {{ .metadata.name }} = api"),
+		SkipConfirm:  true,
+		ResourceType: configMapType,
+		NaturalSort:  true,
+		Streams:      &streams,
+		Audit:        audit,
+	})
+	require.EqualError(t, err, "command failed for 1 of 2 configmaps")
+
+	items := audit.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "prod", items[0].Context)
+	assert.Equal(t, "configmap", items[0].Kind)
+	assert.Equal(t, "default", items[0].Namespace)
+	assert.Equal(t, "api", items[0].Name)
+	assert.Equal(t, AuditOutcomeSucceeded, items[0].Outcome)
+	assert.Empty(t, items[0].Error)
+	assert.False(t, items[0].Time.IsZero())
+
+	assert.Equal(t, "web", items[1].Name)
+	assert.Equal(t, AuditOutcomeFailed, items[1].Outcome)
+	assert.Equal(t, "exit status 1", items[1].Error)
+}
+
+func TestAuditTrail_Contexts(t *testing.T) {
+	audit := NewAuditTrail("")
+	prod, staging := audit.ForContext("prod"), audit.ForContext("staging")
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-1"}}
+
+	prod.Matched("pod", pod)
+	staging.Matched("pod", pod)
+	staging.Done("pod", pod, errors.New("forbidden"))
+	prod.Done("pod", &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "unmatched"}}, nil)
+
+	items := audit.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "prod", items[0].Context)
+	assert.Equal(t, "uid-1", items[0].UID)
+	assert.Equal(t, AuditOutcomeMatched, items[0].Outcome, "only the object in staging was acted on")
+	assert.Equal(t, "staging", items[1].Context)
+	assert.Equal(t, AuditOutcomeFailed, items[1].Outcome)
+	assert.Equal(t, "forbidden", items[1].Error)
+
+	var nilAudit *AuditTrail
+	assert.NotPanics(t, func() {
+		nilAudit.ForContext("prod").Matched("pod", pod)
+		nilAudit.Done("pod", pod, nil)
+	})
+}
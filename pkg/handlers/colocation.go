@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+// colocatedGroup is a set of pods of the same controller running on the same node.
+type colocatedGroup struct {
+	node      string
+	namespace string
+	owner     string // Kind/name of the controller
+	pods      []string
+}
+
+// colocatedPods returns groups of pods that share both the node and the controller, which usually means
+// the spreading expected from anti-affinity or topology spread constraints did not happen.
+// Pods that are not scheduled yet or have no controller are skipped. Groups are sorted by node and owner.
+func colocatedPods(pods []*v1.Pod) []colocatedGroup {
+	type groupKey struct {
+		node  string
+		owner k8s_types.UID
+	}
+	groups := map[groupKey]*colocatedGroup{}
+	for _, pod := range pods {
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || pod.Spec.NodeName == "" {
+			continue
+		}
+		key := groupKey{node: pod.Spec.NodeName, owner: owner.UID}
+		group, found := groups[key]
+		if !found {
+			group = &colocatedGroup{
+				node:      pod.Spec.NodeName,
+				namespace: pod.Namespace,
+				owner:     owner.Kind + "/" + owner.Name,
+			}
+			groups[key] = group
+		}
+		group.pods = append(group.pods, pod.Name)
+	}
+
+	var colocated []colocatedGroup
+	for _, group := range groups {
+		if len(group.pods) > 1 {
+			sort.Strings(group.pods)
+			colocated = append(colocated, *group)
+		}
+	}
+	sort.Slice(colocated, func(i, j int) bool {
+		if colocated[i].node != colocated[j].node {
+			return colocated[i].node < colocated[j].node
+		}
+		if colocated[i].namespace != colocated[j].namespace {
+			return colocated[i].namespace < colocated[j].namespace
+		}
+		return colocated[i].owner < colocated[j].owner
+	})
+	return colocated
+}
+
+// printColocatedPods prints nodes hosting more than one of the pods with the same controller.
+func printColocatedPods(pods []*v1.Pod, out io.Writer) error {
+	groups := colocatedPods(pods)
+	if len(groups) == 0 {
+		_, err := fmt.Fprintln(out, "No nodes host more than one matched pod of the same owner")
+		return err
+	}
+
+	data := make([][]string, len(groups))
+	for i, group := range groups {
+		data[i] = []string{
+			group.node,
+			group.namespace,
+			group.owner,
+			strconv.Itoa(len(group.pods)),
+			strings.Join(group.pods, ", "),
+		}
+	}
+	return printers.RenderTable(out, []string{"NODE", "NAMESPACE", "OWNER", "COUNT", "PODS"}, data)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodHandler_Colocated(t *testing.T) {
+	controller := true
+	pod := func(name, node, owner string) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: node},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				Kind:       "ReplicaSet",
+				Name:       owner,
+				UID:        k8s_types.UID(owner + "-uid"),
+				Controller: &controller,
+			}}
+		}
+		return pod
+	}
+	list := func(t *testing.T, pods ...runtime.Object) string {
+		t.Helper()
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		handler := &PodHandler{clientSet: fake.NewClientset(pods...)}
+		require.NoError(t, handler.HandleAction(t.Context(), ActionOptions{
+			Namespace: "default",
+			Action:    ActionList,
+			Colocated: true,
+			Streams:   &streams,
+		}))
+		return out.String()
+	}
+
+	t.Run("spread pods", func(t *testing.T) {
+		out := list(t,
+			pod("api-1", "node-a", "api-7f9c"),
+			pod("api-2", "node-b", "api-7f9c"),
+			pod("web-1", "node-a", "web-5d8f"),
+		)
+		assert.Equal(t, "No nodes host more than one matched pod of the same owner\n", out)
+	})
+
+	t.Run("co-located pods", func(t *testing.T) {
+		out := list(t,
+			pod("api-1", "node-b", "api-7f9c"),
+			pod("api-2", "node-b", "api-7f9c"),
+			pod("api-3", "node-a", "api-7f9c"),
+			pod("web-1", "node-a", "web-5d8f"),
+			pod("web-2", "node-a", "web-5d8f"),
+			pod("web-3", "node-a", "web-5d8f"),
+			// neither standalone nor pending pods are grouped
+			pod("debug-1", "node-b", ""),
+			pod("debug-2", "node-b", ""),
+			pod("api-4", "", "api-7f9c"),
+		)
+		assert.Equal(t, [][]string{
+			{"NODE", "NAMESPACE", "OWNER", "COUNT", "PODS"},
+			{"node-a", "default", "ReplicaSet/web-5d8f", "3", "web-1,", "web-2,", "web-3"},
+			{"node-b", "default", "ReplicaSet/api-7f9c", "2", "api-1,", "api-2"},
+		}, tableFields(out))
+	})
+}
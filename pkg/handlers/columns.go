@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alikhil/kubectl-find/pkg/printers"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/util/jsonpath"
@@ -33,22 +35,60 @@ func labelToColumnHeader(label string) string {
 	return strings.ToUpper(label)
 }
 
+// podReadyContainers returns how many containers of the pod are ready out of those counting toward readiness,
+// like the READY column of kubectl get. Restartable init containers, i.e. native sidecars, keep running
+// next to the regular containers and count too. Statuses missing while the pod starts count as not ready.
+func podReadyContainers(pod *v1.Pod) (int, int) {
+	total := len(pod.Spec.Containers)
+	ready := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+	}
+
+	sidecarStatuses := make(map[string]v1.ContainerStatus, len(pod.Status.InitContainerStatuses))
+	for _, status := range pod.Status.InitContainerStatuses {
+		sidecarStatuses[status.Name] = status
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy == nil || *container.RestartPolicy != v1.ContainerRestartPolicyAlways {
+			continue
+		}
+		total++
+		if status, found := sidecarStatuses[container.Name]; found && status.Ready {
+			ready++
+		}
+	}
+	return ready, total
+}
+
+// podReadinessGates returns how many readiness gates of the pod are satisfied by a True condition
+// of the same type, out of all gates, like the READINESS GATES column of kubectl get -o wide.
+func podReadinessGates(pod *v1.Pod) (int, int) {
+	satisfied := 0
+	for _, gate := range pod.Spec.ReadinessGates {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType && condition.Status == v1.ConditionTrue {
+				satisfied++
+				break
+			}
+		}
+	}
+	return satisfied, len(pod.Spec.ReadinessGates)
+}
+
 func getColumnsForPods(opts HandlerOptions) []printers.Column {
+	pods := newRowCache[v1.Pod]()
 	columns := []printers.Column{
 		{
 			Header: "READY",
 			Value: func(obj unstructured.Unstructured) string {
-				pod, err := toPod(obj)
+				pod, err := pods.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
-				totalContainers := len(pod.Spec.Containers)
-				readyContainers := 0
-				for _, cs := range pod.Status.ContainerStatuses {
-					if cs.Ready {
-						readyContainers++
-					}
-				}
+				readyContainers, totalContainers := podReadyContainers(pod)
 				return fmt.Sprintf("%d/%d", readyContainers, totalContainers)
 			},
 		},
@@ -64,23 +104,65 @@ func getColumnsForPods(opts HandlerOptions) []printers.Column {
 		{
 			Header: "RESTARTS",
 			Value: func(obj unstructured.Unstructured) string {
-				pod, err := toPod(obj)
+				pod, err := pods.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
-				totalRestarts := 0
-				for _, cs := range pod.Status.ContainerStatuses {
-					totalRestarts += int(cs.RestartCount)
-				}
-				return strconv.Itoa(totalRestarts)
+				return strconv.Itoa(int(podRestarts(pod)))
 			},
 		},
 	}
+	if opts.wide {
+		columns = append(columns,
+			printers.Column{
+				Header: "IP",
+				Value: func(obj unstructured.Unstructured) string {
+					if ip, found, _ := unstructured.NestedString(obj.Object, "status", "podIP"); found && ip != "" {
+						return ip
+					}
+					return NoneStr
+				},
+			},
+			printers.Column{
+				Header: "NODE",
+				Value: func(obj unstructured.Unstructured) string {
+					if node, found, _ := unstructured.NestedString(obj.Object, "spec", "nodeName"); found && node != "" {
+						return node
+					}
+					return NoneStr
+				},
+			},
+			printers.Column{
+				Header: "NOMINATED NODE",
+				Value: func(obj unstructured.Unstructured) string {
+					node, found, _ := unstructured.NestedString(obj.Object, "status", "nominatedNodeName")
+					if found && node != "" {
+						return node
+					}
+					return NoneStr
+				},
+			},
+			printers.Column{
+				Header: "READINESS GATES",
+				Value: func(obj unstructured.Unstructured) string {
+					pod, err := pods.get(obj)
+					if err != nil {
+						return UnknownStr
+					}
+					satisfied, total := podReadinessGates(pod)
+					if total == 0 {
+						return NoneStr
+					}
+					return fmt.Sprintf("%d/%d", satisfied, total)
+				},
+			},
+		)
+	}
 	if opts.withImages {
 		columns = append(columns, printers.Column{
 			Header: "IMAGES",
 			Value: func(obj unstructured.Unstructured) string {
-				pod, err := toPod(obj)
+				pod, err := pods.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -178,14 +260,6 @@ func getReplicaCountOrDefault(replicas *int32) int32 {
 	return *replicas
 }
 
-func toPod(obj unstructured.Unstructured) (*v1.Pod, error) {
-	pod := &v1.Pod{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
-		return nil, err
-	}
-	return pod, nil
-}
-
 func toDeployment(obj unstructured.Unstructured) (*appsv1.Deployment, error) {
 	deployment := &appsv1.Deployment{}
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
@@ -202,14 +276,6 @@ func toStatefulSet(obj unstructured.Unstructured) (*appsv1.StatefulSet, error) {
 	return statefulSet, nil
 }
 
-func toReplicaSet(obj unstructured.Unstructured) (*appsv1.ReplicaSet, error) {
-	replicaSet := &appsv1.ReplicaSet{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, replicaSet); err != nil {
-		return nil, err
-	}
-	return replicaSet, nil
-}
-
 func toDaemonSet(obj unstructured.Unstructured) (*appsv1.DaemonSet, error) {
 	daemonSet := &appsv1.DaemonSet{}
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, daemonSet); err != nil {
@@ -218,12 +284,35 @@ func toDaemonSet(obj unstructured.Unstructured) (*appsv1.DaemonSet, error) {
 	return daemonSet, nil
 }
 
-func getColumnsForDeployments() []printers.Column {
+// getGenerationColumns returns the GENERATION and OBSERVED columns of workloads, showing metadata.generation
+// and status.observedGeneration. Differing values mean the controller has not reconciled the latest spec yet.
+func getGenerationColumns() []printers.Column {
 	return []printers.Column{
+		{
+			Header: "GENERATION",
+			Value: func(obj unstructured.Unstructured) string {
+				return strconv.FormatInt(obj.GetGeneration(), 10)
+			},
+		},
+		{
+			Header: "OBSERVED",
+			Value: func(obj unstructured.Unstructured) string {
+				if observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); found {
+					return strconv.FormatInt(observed, 10)
+				}
+				return NoneStr
+			},
+		},
+	}
+}
+
+func getColumnsForDeployments(opts HandlerOptions) []printers.Column {
+	deployments := newRowCache[appsv1.Deployment]()
+	columns := []printers.Column{
 		{
 			Header: "READY",
 			Value: func(obj unstructured.Unstructured) string {
-				deployment, err := toDeployment(obj)
+				deployment, err := deployments.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -237,7 +326,7 @@ func getColumnsForDeployments() []printers.Column {
 		{
 			Header: "UP-TO-DATE",
 			Value: func(obj unstructured.Unstructured) string {
-				deployment, err := toDeployment(obj)
+				deployment, err := deployments.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -247,7 +336,7 @@ func getColumnsForDeployments() []printers.Column {
 		{
 			Header: "AVAILABLE",
 			Value: func(obj unstructured.Unstructured) string {
-				deployment, err := toDeployment(obj)
+				deployment, err := deployments.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -255,14 +344,28 @@ func getColumnsForDeployments() []printers.Column {
 			},
 		},
 	}
+	if opts.wide {
+		columns = append(columns, getGenerationColumns()...)
+		columns = append(columns, printers.Column{
+			Header: "REVISION",
+			Value: func(obj unstructured.Unstructured) string {
+				if revision := obj.GetAnnotations()[revisionAnnotation]; revision != "" {
+					return revision
+				}
+				return NoneStr
+			},
+		})
+	}
+	return columns
 }
 
-func getColumnsForStatefulSets() []printers.Column {
-	return []printers.Column{
+func getColumnsForStatefulSets(opts HandlerOptions) []printers.Column {
+	statefulSets := newRowCache[appsv1.StatefulSet]()
+	columns := []printers.Column{
 		{
 			Header: "READY",
 			Value: func(obj unstructured.Unstructured) string {
-				statefulSet, err := toStatefulSet(obj)
+				statefulSet, err := statefulSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -274,14 +377,30 @@ func getColumnsForStatefulSets() []printers.Column {
 			},
 		},
 	}
+	if opts.wide {
+		columns = append(columns, getGenerationColumns()...)
+		// stateful sets have no revision annotation, the controller revision pods are updated to is shown instead
+		columns = append(columns, printers.Column{
+			Header: "REVISION",
+			Value: func(obj unstructured.Unstructured) string {
+				revision, found, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+				if found && revision != "" {
+					return revision
+				}
+				return NoneStr
+			},
+		})
+	}
+	return columns
 }
 
 func getColumnsForReplicaSets() []printers.Column {
+	replicaSets := newRowCache[appsv1.ReplicaSet]()
 	return []printers.Column{
 		{
 			Header: "DESIRED",
 			Value: func(obj unstructured.Unstructured) string {
-				replicaSet, err := toReplicaSet(obj)
+				replicaSet, err := replicaSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -291,7 +410,7 @@ func getColumnsForReplicaSets() []printers.Column {
 		{
 			Header: "CURRENT",
 			Value: func(obj unstructured.Unstructured) string {
-				replicaSet, err := toReplicaSet(obj)
+				replicaSet, err := replicaSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -301,7 +420,7 @@ func getColumnsForReplicaSets() []printers.Column {
 		{
 			Header: "READY",
 			Value: func(obj unstructured.Unstructured) string {
-				replicaSet, err := toReplicaSet(obj)
+				replicaSet, err := replicaSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -311,14 +430,6 @@ func getColumnsForReplicaSets() []printers.Column {
 	}
 }
 
-func toNode(obj unstructured.Unstructured) (*v1.Node, error) {
-	node := &v1.Node{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, node); err != nil {
-		return nil, err
-	}
-	return node, nil
-}
-
 func getNodeStatus(node *v1.Node) string {
 	conditionMap := make(map[v1.NodeConditionType]v1.ConditionStatus)
 	for _, condition := range node.Status.Conditions {
@@ -355,11 +466,12 @@ func getNodeRoles(node *v1.Node) string {
 }
 
 func getColumnsForNodes() []printers.Column {
+	nodes := newRowCache[v1.Node]()
 	return []printers.Column{
 		{
 			Header: "STATUS",
 			Value: func(obj unstructured.Unstructured) string {
-				node, err := toNode(obj)
+				node, err := nodes.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -369,7 +481,7 @@ func getColumnsForNodes() []printers.Column {
 		{
 			Header: "ROLES",
 			Value: func(obj unstructured.Unstructured) string {
-				node, err := toNode(obj)
+				node, err := nodes.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -380,11 +492,12 @@ func getColumnsForNodes() []printers.Column {
 }
 
 func getSuffixColumnsForNodes() []printers.Column {
+	nodes := newRowCache[v1.Node]()
 	return []printers.Column{
 		{
 			Header: "VERSION",
 			Value: func(obj unstructured.Unstructured) string {
-				node, err := toNode(obj)
+				node, err := nodes.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -395,11 +508,12 @@ func getSuffixColumnsForNodes() []printers.Column {
 }
 
 func getColumnsForDaemonSets() []printers.Column {
+	daemonSets := newRowCache[appsv1.DaemonSet]()
 	return []printers.Column{
 		{
 			Header: "DESIRED",
 			Value: func(obj unstructured.Unstructured) string {
-				daemonSet, err := toDaemonSet(obj)
+				daemonSet, err := daemonSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -409,7 +523,7 @@ func getColumnsForDaemonSets() []printers.Column {
 		{
 			Header: "CURRENT",
 			Value: func(obj unstructured.Unstructured) string {
-				daemonSet, err := toDaemonSet(obj)
+				daemonSet, err := daemonSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -419,7 +533,7 @@ func getColumnsForDaemonSets() []printers.Column {
 		{
 			Header: "READY",
 			Value: func(obj unstructured.Unstructured) string {
-				daemonSet, err := toDaemonSet(obj)
+				daemonSet, err := daemonSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -429,7 +543,7 @@ func getColumnsForDaemonSets() []printers.Column {
 		{
 			Header: "UP-TO-DATE",
 			Value: func(obj unstructured.Unstructured) string {
-				daemonSet, err := toDaemonSet(obj)
+				daemonSet, err := daemonSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -439,7 +553,7 @@ func getColumnsForDaemonSets() []printers.Column {
 		{
 			Header: "AVAILABLE",
 			Value: func(obj unstructured.Unstructured) string {
-				daemonSet, err := toDaemonSet(obj)
+				daemonSet, err := daemonSets.get(obj)
 				if err != nil {
 					return UnknownStr
 				}
@@ -537,9 +651,9 @@ func GetColumnsFor(opts HandlerOptions, resourceType Resource) []printers.Column
 	case ServiceType:
 		return getColumnsForServices(opts)
 	case DeploymentType:
-		return getColumnsForDeployments()
+		return getColumnsForDeployments(opts)
 	case StatefulSetType:
-		return getColumnsForStatefulSets()
+		return getColumnsForStatefulSets(opts)
 	case ReplicaSetType:
 		return getColumnsForReplicaSets()
 	case DaemonSetType:
@@ -612,22 +726,25 @@ func getColumnsFromCRD(opts HandlerOptions, resourceType schema.GroupVersionReso
 			continue
 		}
 
-		return convertCRDColumnsToTableColumns(version.AdditionalPrinterColumns)
+		return convertCRDColumnsToTableColumns(version.AdditionalPrinterColumns, opts.wide)
 	}
 
 	return nil
 }
 
-func convertCRDColumnsToTableColumns(crdColumns []apiextensionsv1.CustomResourceColumnDefinition) []printers.Column {
+// convertCRDColumnsToTableColumns converts CRD additionalPrinterColumns to table columns.
+// Low priority columns (priority > 0) are only included in wide mode, same as `kubectl get -o wide`.
+func convertCRDColumnsToTableColumns(
+	crdColumns []apiextensionsv1.CustomResourceColumnDefinition,
+	wide bool,
+) []printers.Column {
 	var columns []printers.Column
 
 	for _, col := range crdColumns {
 		name := col.Name
 		jsonPathStr := col.JSONPath
 
-		if col.Priority > 0 {
-			// skipping low priority columns for now
-			// todo: add option to include them possible with -o wide flag
+		if col.Priority > 0 && !wide {
 			continue
 		}
 
@@ -643,6 +760,16 @@ func convertCRDColumnsToTableColumns(crdColumns []apiextensionsv1.CustomResource
 			continue
 		}
 
+		if col.Type == "date" {
+			columns = append(columns, printers.Column{
+				Header: strings.ToUpper(name),
+				Value: func(obj unstructured.Unstructured) string {
+					return extractAgeFromJSONPath(obj, jp)
+				},
+			})
+			continue
+		}
+
 		columns = append(columns, printers.Column{
 			Header: strings.ToUpper(name),
 			Value: func(obj unstructured.Unstructured) string {
@@ -654,6 +781,23 @@ func convertCRDColumnsToTableColumns(crdColumns []apiextensionsv1.CustomResource
 	return columns
 }
 
+// extractAgeFromJSONPath renders a timestamp found by the JSONPath as a human readable age, like the AGE column.
+func extractAgeFromJSONPath(obj unstructured.Unstructured, jp *jsonpath.JSONPath) string {
+	return formatAge(extractValueFromJSONPath(obj, jp))
+}
+
+// formatAge renders an RFC 3339 timestamp as a human readable age; other values are returned as is.
+func formatAge(value string) string {
+	if value == NoneStr {
+		return NoneStr
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return duration.HumanDuration(time.Since(timestamp))
+}
+
 func extractValueFromJSONPath(obj unstructured.Unstructured, jp *jsonpath.JSONPath) string {
 	// Execute the JSONPath query
 	results, err := jp.FindResults(obj.UnstructuredContent())
@@ -786,3 +930,62 @@ func GetAnnotationColumns(opts HandlerOptions) []printers.Column {
 	}
 	return columns
 }
+
+func GetOwnerColumns(opts HandlerOptions) []printers.Column {
+	if !opts.showOwner {
+		return nil
+	}
+	return []printers.Column{
+		{
+			Header: "OWNER",
+			Value: func(obj unstructured.Unstructured) string {
+				for _, owner := range obj.GetOwnerReferences() {
+					if owner.Controller != nil && *owner.Controller {
+						return owner.Kind + "/" + owner.Name
+					}
+				}
+				return NoneStr
+			},
+		},
+	}
+}
+
+// maxShownConditions limits the CONDITIONS column, resources like nodes or CRDs can report many conditions.
+const maxShownConditions = 5
+
+func GetConditionsColumns(opts HandlerOptions) []printers.Column {
+	if !opts.showConditions {
+		return nil
+	}
+	return []printers.Column{
+		{
+			Header: "CONDITIONS",
+			Value: func(obj unstructured.Unstructured) string {
+				conditions, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+				if !found {
+					return NoneStr
+				}
+				var summary []string
+				for _, c := range conditions {
+					condition, ok := c.(map[string]any)
+					if !ok {
+						continue
+					}
+					conditionType, _ := condition["type"].(string)
+					status, _ := condition["status"].(string)
+					if conditionType == "" {
+						continue
+					}
+					summary = append(summary, conditionType+"="+status)
+				}
+				if len(summary) == 0 {
+					return NoneStr
+				}
+				if len(summary) > maxShownConditions {
+					return strings.Join(summary[:maxShownConditions], ",") + " ..."
+				}
+				return strings.Join(summary, ",")
+			},
+		},
+	}
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
+	"github.com/alikhil/kubectl-find/pkg/printers"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -95,6 +100,164 @@ func Test_GetColumnsForPods_MatchesKubectlGet(t *testing.T) {
 	require.Equal(t, "3", columns[2].Value(obj))
 }
 
+func TestPodReadyContainers(t *testing.T) {
+	always := v1.ContainerRestartPolicyAlways
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want string
+	}{
+		{
+			name: "starting without container statuses",
+			pod: &v1.Pod{
+				Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "proxy"}}},
+				Status: v1.PodStatus{Phase: v1.PodPending},
+			},
+			want: "0/2",
+		},
+		{
+			name: "starting with some container statuses",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "proxy"}}},
+				Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+					{Name: "proxy", Ready: true},
+				}},
+			},
+			want: "1/2",
+		},
+		{
+			name: "init container does not count",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{{Name: "migrate"}},
+					Containers:     []v1.Container{{Name: "app"}},
+				},
+				Status: v1.PodStatus{
+					InitContainerStatuses: []v1.ContainerStatus{{Name: "migrate"}},
+					ContainerStatuses:     []v1.ContainerStatus{{Name: "app", Ready: true}},
+				},
+			},
+			want: "1/1",
+		},
+		{
+			name: "sidecar not ready",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{{Name: "istio-proxy", RestartPolicy: &always}},
+					Containers:     []v1.Container{{Name: "app"}},
+				},
+				Status: v1.PodStatus{
+					InitContainerStatuses: []v1.ContainerStatus{{Name: "istio-proxy"}},
+					ContainerStatuses:     []v1.ContainerStatus{{Name: "app", Ready: true}},
+				},
+			},
+			want: "1/2",
+		},
+		{
+			name: "fully ready with sidecar",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{
+						{Name: "migrate"},
+						{Name: "istio-proxy", RestartPolicy: &always},
+					},
+					Containers: []v1.Container{{Name: "app"}, {Name: "worker"}},
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					InitContainerStatuses: []v1.ContainerStatus{
+						{Name: "migrate"},
+						{Name: "istio-proxy", Ready: true},
+					},
+					ContainerStatuses: []v1.ContainerStatus{
+						{Name: "app", Ready: true},
+						{Name: "worker", Ready: true},
+					},
+				},
+			},
+			want: "3/3",
+		},
+	}
+
+	columns := GetColumnsFor(HandlerOptions{}, Resource{GroupVersionResource: PodType})
+	require.Equal(t, "READY", columns[0].Header)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, columns[0].Value(toUnstructured(t, tt.pod)))
+		})
+	}
+}
+
+func Test_GetColumnsForPods_Wide(t *testing.T) {
+	scheduled := &v1.Pod{
+		Spec:   v1.PodSpec{NodeName: "worker-1"},
+		Status: v1.PodStatus{PodIP: "10.0.0.7"},
+	}
+
+	columns := GetColumnsFor(HandlerOptions{}.WithWide(true), Resource{GroupVersionResource: PodType})
+	require.Len(t, columns, 7)
+	require.Equal(t, "IP", columns[3].Header)
+	require.Equal(t, "10.0.0.7", columns[3].Value(toUnstructured(t, scheduled)))
+	require.Equal(t, "NODE", columns[4].Header)
+	require.Equal(t, "worker-1", columns[4].Value(toUnstructured(t, scheduled)))
+
+	pending := toUnstructured(t, &v1.Pod{})
+	require.Equal(t, NoneStr, columns[3].Value(pending))
+	require.Equal(t, NoneStr, columns[4].Value(pending))
+}
+
+func Test_GetColumnsForPods_WideNominatedNode(t *testing.T) {
+	columns := GetColumnsFor(HandlerOptions{}.WithWide(true), Resource{GroupVersionResource: PodType})
+	require.Equal(t, "NOMINATED NODE", columns[5].Header)
+
+	preempting := &v1.Pod{Status: v1.PodStatus{NominatedNodeName: "worker-2"}}
+	require.Equal(t, "worker-2", columns[5].Value(toUnstructured(t, preempting)))
+	require.Equal(t, NoneStr, columns[5].Value(toUnstructured(t, &v1.Pod{})))
+}
+
+func Test_GetColumnsForPods_WideReadinessGates(t *testing.T) {
+	columns := GetColumnsFor(HandlerOptions{}.WithWide(true), Resource{GroupVersionResource: PodType})
+	require.Equal(t, "READINESS GATES", columns[6].Header)
+
+	gates := []v1.PodReadinessGate{
+		{ConditionType: "target-health.elbv2.k8s.aws/web"},
+		{ConditionType: "example.com/warmed-up"},
+	}
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want string
+	}{
+		{
+			name: "no readiness gates",
+			pod:  &v1.Pod{},
+			want: NoneStr,
+		},
+		{
+			name: "no gate conditions reported yet",
+			pod:  &v1.Pod{Spec: v1.PodSpec{ReadinessGates: gates}},
+			want: "0/2",
+		},
+		{
+			name: "one gate satisfied",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{ReadinessGates: gates},
+				Status: v1.PodStatus{Conditions: []v1.PodCondition{
+					{Type: "target-health.elbv2.k8s.aws/web", Status: v1.ConditionTrue},
+					{Type: "example.com/warmed-up", Status: v1.ConditionFalse},
+					{Type: v1.PodReady, Status: v1.ConditionTrue},
+				}},
+			},
+			want: "1/2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, columns[6].Value(toUnstructured(t, tt.pod)))
+		})
+	}
+}
+
 func Test_GetColumnsForDeployments(t *testing.T) {
 	replicas := int32(3)
 	deployment := &appsv1.Deployment{
@@ -141,6 +304,63 @@ func Test_GetColumnsForStatefulSets(t *testing.T) {
 	require.Equal(t, "2/4", columns[0].Value(obj))
 }
 
+func Test_GetColumnsForWorkloads_WideGeneration(t *testing.T) {
+	reconciled := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation:  4,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 4},
+	}
+	stuck := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 7},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 6,
+			UpdateRevision:     "db-5d8f7c9b4",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resource schema.GroupVersionResource
+		object   runtime.Object
+		want     []string
+	}{
+		{
+			name:     "deployment with matching generations",
+			resource: DeploymentType,
+			object:   reconciled,
+			want:     []string{"4", "4", "3"},
+		},
+		{
+			name:     "stateful set with mismatched generations",
+			resource: StatefulSetType,
+			object:   stuck,
+			want:     []string{"7", "6", "db-5d8f7c9b4"},
+		},
+		{
+			name:     "deployment never observed",
+			resource: DeploymentType,
+			object:   &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			want:     []string{"1", NoneStr, NoneStr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := GetColumnsFor(HandlerOptions{}.WithWide(true), Resource{GroupVersionResource: tt.resource})
+			wide := columns[len(columns)-3:]
+			obj := toUnstructured(t, tt.object)
+			for i, header := range []string{"GENERATION", "OBSERVED", "REVISION"} {
+				require.Equal(t, header, wide[i].Header)
+				require.Equal(t, tt.want[i], wide[i].Value(obj), header)
+			}
+		})
+	}
+
+	require.Len(t, GetColumnsFor(HandlerOptions{}, Resource{GroupVersionResource: DeploymentType}), 3)
+	require.Len(t, GetColumnsFor(HandlerOptions{}, Resource{GroupVersionResource: StatefulSetType}), 1)
+}
+
 func Test_GetColumnsForReplicaSets(t *testing.T) {
 	replicas := int32(5)
 	replicaSet := &appsv1.ReplicaSet{
@@ -452,3 +672,170 @@ func Test_GetAnnotationColumns(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetHealthColumns(t *testing.T) {
+	replicas := int32(3)
+	healthy := &appsv1.Deployment{
+		Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 3, AvailableReplicas: 3},
+	}
+	unhealthy := &appsv1.Deployment{
+		Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1, UnavailableReplicas: 2},
+	}
+
+	columns := getHealthColumns(DeploymentType)
+	require.Len(t, columns, 1)
+
+	require.Equal(t, "HEALTH", columns[0].Header)
+	require.Equal(t, "Healthy", columns[0].Value(toUnstructured(t, healthy)))
+	require.Equal(t, "Unhealthy (1/3 ready, 2 unavailable)", columns[0].Value(toUnstructured(t, unhealthy)))
+}
+
+func Test_convertCRDColumnsToTableColumns_Priority(t *testing.T) {
+	crdColumns := []apiextensionsv1.CustomResourceColumnDefinition{
+		{Name: "Ready", Type: "string", JSONPath: ".status.ready"},
+		{Name: "Issuer", Type: "string", JSONPath: ".spec.issuerRef.name", Priority: 1},
+		{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+	}
+
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"issuerRef": map[string]interface{}{"name": "letsencrypt"}},
+		"status": map[string]interface{}{"ready": "True"},
+	}}
+
+	columns := convertCRDColumnsToTableColumns(crdColumns, false)
+	require.Len(t, columns, 1)
+	require.Equal(t, "READY", columns[0].Header)
+
+	columns = convertCRDColumnsToTableColumns(crdColumns, true)
+	require.Len(t, columns, 2)
+	require.Equal(t, "READY", columns[0].Header)
+	require.Equal(t, "True", columns[0].Value(obj))
+	require.Equal(t, "ISSUER", columns[1].Header)
+	require.Equal(t, "letsencrypt", columns[1].Value(obj))
+}
+
+func Test_convertCRDColumnsToTableColumns_DateColumn(t *testing.T) {
+	crdColumns := []apiextensionsv1.CustomResourceColumnDefinition{
+		{Name: "Created", Type: "date", JSONPath: ".status.createdAt"},
+		{Name: "Renewal", Type: "date", JSONPath: ".status.renewalTime"},
+	}
+
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"createdAt": time.Now().Add(-49 * time.Hour).UTC().Format(time.RFC3339),
+		},
+	}}
+
+	columns := convertCRDColumnsToTableColumns(crdColumns, false)
+	require.Len(t, columns, 2)
+	require.Equal(t, "CREATED", columns[0].Header)
+	require.Equal(t, "2d1h", columns[0].Value(obj))
+	require.Equal(t, "RENEWAL", columns[1].Header)
+	require.Equal(t, NoneStr, columns[1].Value(obj))
+}
+
+func Test_GetOwnerColumns(t *testing.T) {
+	controller := true
+	withOwner := unstructured.Unstructured{}
+	withOwner.SetOwnerReferences([]metav1.OwnerReference{
+		{Kind: "Node", Name: "node-1"},
+		{Kind: "ReplicaSet", Name: "web-abc123", Controller: &controller},
+	})
+	withoutController := unstructured.Unstructured{}
+	withoutController.SetOwnerReferences([]metav1.OwnerReference{
+		{Kind: "Node", Name: "node-1"},
+	})
+
+	require.Empty(t, GetOwnerColumns(HandlerOptions{}))
+
+	columns := GetOwnerColumns(HandlerOptions{showOwner: true})
+	require.Len(t, columns, 1)
+
+	require.Equal(t, "OWNER", columns[0].Header)
+	require.Equal(t, "ReplicaSet/web-abc123", columns[0].Value(withOwner))
+	require.Equal(t, NoneStr, columns[0].Value(withoutController))
+}
+
+func BenchmarkTablePrinter_Deployments(b *testing.B) {
+	replicas := int32(3)
+	objects := make([]unstructured.Unstructured, 10000)
+	for i := range objects {
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("web-%d", i), Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+		})
+		require.NoError(b, err)
+		objects[i] = unstructured.Unstructured{Object: raw}
+	}
+	printer := printers.NewTablePrinter(printers.TablePrinterOptions{
+		AdditionalColumns: getColumnsForDeployments(HandlerOptions{}),
+	})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := printer.PrintObjects(b.Context(), objects, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTablePrinter_Pods(b *testing.B) {
+	objects := make([]unstructured.Unstructured, 10000)
+	for i := range objects {
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("web-%d", i), Namespace: "default"},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "web", Image: "nginx:1.27"}, {Name: "sidecar", Image: "envoy:1.31"}},
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "web", Ready: true, RestartCount: 1},
+					{Name: "sidecar", Ready: true},
+				},
+			},
+		})
+		require.NoError(b, err)
+		objects[i] = unstructured.Unstructured{Object: raw}
+	}
+	printer := printers.NewTablePrinter(printers.TablePrinterOptions{
+		AdditionalColumns: getColumnsForPods(HandlerOptions{}.WithImages(true)),
+	})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := printer.PrintObjects(b.Context(), objects, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Test_GetConditionsColumns(t *testing.T) {
+	deployment := unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+				map[string]any{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+			},
+		},
+	}}
+	manyConditions := unstructured.Unstructured{Object: map[string]any{}}
+	var conditions []any
+	for _, conditionType := range []string{"A", "B", "C", "D", "E", "F"} {
+		conditions = append(conditions, map[string]any{"type": conditionType, "status": "True"})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(manyConditions.Object, conditions, "status", "conditions"))
+
+	require.Empty(t, GetConditionsColumns(HandlerOptions{}))
+
+	columns := GetConditionsColumns(HandlerOptions{showConditions: true})
+	require.Len(t, columns, 1)
+
+	require.Equal(t, "CONDITIONS", columns[0].Header)
+	require.Equal(t, "Available=True,Progressing=False", columns[0].Value(deployment))
+	require.Equal(t, "A=True,B=True,C=True,D=True,E=True ...", columns[0].Value(manyConditions))
+	require.Equal(t, NoneStr, columns[0].Value(unstructured.Unstructured{Object: map[string]any{}}))
+}
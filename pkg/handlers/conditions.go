@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AnyConditionStatus matches a condition of the given type regardless of its status, e.g. --condition Ready=*.
+const AnyConditionStatus = "*"
+
+// ConditionAge filters resources whose condition has had the status for at least MinAge,
+// according to its lastTransitionTime, e.g. --condition-age Ready=False:10m.
+type ConditionAge struct {
+	Type   string
+	Status string // AnyConditionStatus matches any status of the type
+	MinAge time.Duration
+}
+
+// conditionTransition is the lowercased status of a condition and the last time it changed.
+type conditionTransition struct {
+	status             string
+	lastTransitionTime time.Time
+}
+
+// resourceConditions returns the statuses and last transition times of status.conditions by condition type,
+// both lowercased. A missing or malformed lastTransitionTime is left zero.
+func resourceConditions(resource unstructured.Unstructured) map[string]conditionTransition {
+	conditionsRaw, _, _ := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	conditions := make(map[string]conditionTransition, len(conditionsRaw))
+	for _, c := range conditionsRaw {
+		cMap, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cType, _ := cMap["type"].(string)
+		cStatus, _ := cMap["status"].(string)
+		cTime, _ := cMap["lastTransitionTime"].(string)
+		if cType == "" {
+			continue
+		}
+		condition := conditionTransition{status: strings.ToLower(cStatus)}
+		if parsed, err := time.Parse(time.RFC3339, cTime); err == nil {
+			condition.lastTransitionTime = parsed
+		}
+		conditions[strings.ToLower(cType)] = condition
+	}
+	return conditions
+}
+
+// podConditions returns the statuses and last transition times of pod conditions
+// like resourceConditions does for other resources.
+func podConditions(pod *v1.Pod) map[string]conditionTransition {
+	conditions := make(map[string]conditionTransition, len(pod.Status.Conditions))
+	for _, condition := range pod.Status.Conditions {
+		conditions[strings.ToLower(string(condition.Type))] = conditionTransition{
+			status:             strings.ToLower(string(condition.Status)),
+			lastTransitionTime: condition.LastTransitionTime.Time,
+		}
+	}
+	return conditions
+}
+
+// conditionsMatch returns true if every filter is satisfied by the conditions returned by resourceConditions.
+// Comparison is case-insensitive, a filter with AnyConditionStatus only requires the condition to be present.
+func conditionsMatch(conditions map[string]conditionTransition, filters []NodeCondition) bool {
+	for _, filter := range filters {
+		actual, exists := conditions[strings.ToLower(filter.Type)]
+		if !exists {
+			return false
+		}
+		if filter.Status != AnyConditionStatus && actual.status != strings.ToLower(filter.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionAgesMatch returns true if every condition of the filters has had the filtered status
+// for at least its minimum age. Conditions without a lastTransitionTime never match.
+func conditionAgesMatch(transitions map[string]conditionTransition, filters []ConditionAge) bool {
+	for _, filter := range filters {
+		transition, exists := transitions[strings.ToLower(filter.Type)]
+		if !exists || transition.lastTransitionTime.IsZero() {
+			return false
+		}
+		if filter.Status != AnyConditionStatus && transition.status != strings.ToLower(filter.Status) {
+			return false
+		}
+		if time.Since(transition.lastTransitionTime) < filter.MinAge {
+			return false
+		}
+	}
+	return true
+}
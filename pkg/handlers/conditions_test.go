@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestConditionAges_Pods(t *testing.T) {
+	podWithReady := func(status v1.ConditionStatus, since time.Duration) *v1.Pod {
+		return &v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{{
+			Type:               v1.PodReady,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+		}}}}
+	}
+	options := ActionOptions{ConditionAges: []ConditionAge{{Type: "Ready", Status: "False", MinAge: 10 * time.Minute}}}
+	match := func(pod *v1.Pod) bool {
+		return (&PodHandler{}).getMatcher(options)(pod)
+	}
+
+	assert.True(t, match(podWithReady(v1.ConditionFalse, time.Hour)), "not ready for an hour")
+	assert.False(t, match(podWithReady(v1.ConditionFalse, time.Minute)), "not ready for a minute")
+	assert.False(t, match(podWithReady(v1.ConditionTrue, time.Hour)), "ready for an hour")
+	assert.False(t, match(&v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{
+		{Type: v1.PodReady, Status: v1.ConditionFalse},
+	}}}), "no transition time")
+	assert.False(t, match(&v1.Pod{}), "no conditions")
+}
+
+func TestConditionAges_Resources(t *testing.T) {
+	nodeWithReady := func(status string, since time.Duration) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{
+					"type":               "Ready",
+					"status":             status,
+					"lastTransitionTime": time.Now().Add(-since).UTC().Format(time.RFC3339),
+				}},
+			},
+		}}
+	}
+	handler := &UniversalHandler{}
+	options := ActionOptions{ConditionAges: []ConditionAge{{Type: "ready", Status: "false", MinAge: 10 * time.Minute}}}
+
+	assert.True(t, handler.resourceMatches(nodeWithReady("False", time.Hour), &options), "not ready for an hour")
+	assert.False(t, handler.resourceMatches(nodeWithReady("False", time.Minute), &options), "not ready for a minute")
+	assert.False(t, handler.resourceMatches(nodeWithReady("True", time.Hour), &options), "ready for an hour")
+
+	options.ConditionAges[0].Status = AnyConditionStatus
+	assert.True(t, handler.resourceMatches(nodeWithReady("True", time.Hour), &options), "any status for an hour")
+	assert.False(t, handler.resourceMatches(nodeWithReady("True", time.Minute), &options), "any status for a minute")
+}
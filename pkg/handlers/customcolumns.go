@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ageModifier renders the timestamp found by a custom column JSONPath as a human readable age, like the AGE column.
+const ageModifier = ":age"
+
+// ParseCustomColumns parses a custom columns spec in kubectl format: HEADER:JSONPATH[,HEADER2:JSONPATH2].
+// A JSONPath may be followed by the :age modifier to print a timestamp as age, e.g. AGE:.metadata.creationTimestamp:age.
+// Unlike CRD printer columns, user provided JSONPath expressions are validated strictly
+// and the first malformed expression is reported as an error.
+func ParseCustomColumns(spec string) ([]printers.Column, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, errors.New("custom columns spec cannot be empty")
+	}
+
+	var columns []printers.Column
+	for _, part := range splitTopLevel(spec) {
+		header, expr, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q: expected HEADER:JSONPATH", part)
+		}
+
+		expr, age := strings.CutSuffix(expr, ageModifier)
+		if expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q: expected HEADER:JSONPATH", part)
+		}
+
+		jp, err := parseColumnJSONPath(header, expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jsonpath %q for column %q: %w", expr, header, err)
+		}
+
+		extract := extractValueFromJSONPath
+		if age {
+			extract = extractAgeFromJSONPath
+		}
+		columns = append(columns, printers.Column{
+			Header: strings.ToUpper(header),
+			Value: func(obj unstructured.Unstructured) string {
+				return extract(obj, jp)
+			},
+		})
+	}
+	return columns, nil
+}
+
+func parseColumnJSONPath(name, expr string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expr, "{") {
+		expr = fmt.Sprintf("{%s}", expr)
+	}
+	jp := jsonpath.New(name)
+	if err := jp.Parse(expr); err != nil {
+		return nil, err
+	}
+	return jp, nil
+}
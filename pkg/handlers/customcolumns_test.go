@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+	}
+
+	columns, err := ParseCustomColumns("name:.metadata.name,NODE:{.spec.nodeName},IP:.status.podIP")
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+
+	obj := toUnstructured(t, pod)
+	assert.Equal(t, "NAME", columns[0].Header)
+	assert.Equal(t, "web", columns[0].Value(obj))
+	assert.Equal(t, "NODE", columns[1].Header)
+	assert.Equal(t, "node-1", columns[1].Value(obj))
+	assert.Equal(t, "IP", columns[2].Header)
+	assert.Equal(t, NoneStr, columns[2].Value(obj))
+}
+
+func TestParseCustomColumns_NestedCommas(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "app", Image: "nginx"},
+			{Name: "proxy", Image: "envoy"},
+		}},
+	}
+
+	columns, err := ParseCustomColumns(
+		`NAME:.metadata.name,IMAGES:{range .spec.containers[*]}{.image}{","}{end},NODE:.spec.nodeName`)
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+
+	obj := toUnstructured(t, pod)
+	assert.Equal(t, "IMAGES", columns[1].Header)
+	assert.Equal(t, "nginx", columns[1].Value(obj), "the first result is printed")
+	assert.Equal(t, "NODE", columns[2].Header)
+}
+
+func TestParseCustomColumns_Age(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-3 * time.Hour)),
+		},
+	}
+
+	columns, err := ParseCustomColumns("NAME:.metadata.name,AGE:.metadata.creationTimestamp:age,STARTED:.status.startTime:age")
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+
+	obj := toUnstructured(t, pod)
+	assert.Equal(t, "AGE", columns[1].Header)
+	assert.Equal(t, "3h", columns[1].Value(obj))
+	assert.Equal(t, NoneStr, columns[2].Value(obj))
+}
+
+func TestParseCustomColumns_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name:    "empty spec",
+			spec:    "",
+			wantErr: "custom columns spec cannot be empty",
+		},
+		{
+			name:    "missing jsonpath",
+			spec:    "NAME:.metadata.name,NODE",
+			wantErr: `invalid custom column "NODE": expected HEADER:JSONPATH`,
+		},
+		{
+			name:    "age modifier without jsonpath",
+			spec:    "AGE::age",
+			wantErr: `invalid custom column "AGE::age": expected HEADER:JSONPATH`,
+		},
+		{
+			name:    "malformed jsonpath",
+			spec:    "NAME:.metadata.name,BROKEN:.spec.containers[",
+			wantErr: `invalid jsonpath ".spec.containers[" for column "BROKEN"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCustomColumns(tt.spec)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
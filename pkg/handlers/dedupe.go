@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+// uniqueByUID drops objects returned more than once by overlapping queries, keeping the first occurrence,
+// so that results aggregated from several lists are never printed, counted or acted on twice.
+// Objects without a UID are always kept.
+func uniqueByUID[T any](items []T, uid func(item T) k8s_types.UID) []T {
+	seen := make(map[k8s_types.UID]bool, len(items))
+	unique := items[:0:0]
+	for _, item := range items {
+		id := uid(item)
+		if id != "" {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+func unstructuredUID(item unstructured.Unstructured) k8s_types.UID { return item.GetUID() }
+
+func podUID(pod v1.Pod) k8s_types.UID { return pod.UID }
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+func TestUniqueByUID(t *testing.T) {
+	pod := func(name string, uid k8s_types.UID) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, UID: uid}}
+	}
+	first := []v1.Pod{pod("web", "uid-web"), pod("api", "uid-api")}
+	second := []v1.Pod{pod("api", "uid-api"), pod("db", "uid-db"), pod("web", "uid-web")}
+
+	unique := uniqueByUID(append(first, second...), podUID)
+
+	names := make([]string, len(unique))
+	for i, pod := range unique {
+		names[i] = pod.Name
+	}
+	assert.Equal(t, []string{"web", "api", "db"}, names)
+}
+
+func TestUniqueByUID_KeepsObjectsWithoutUID(t *testing.T) {
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", UID: "uid-web-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2", UID: "uid-web-2"}},
+	}
+
+	assert.Len(t, uniqueByUID(pods, podUID), 3)
+	assert.Empty(t, uniqueByUID([]v1.Pod{}, podUID))
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pmezard/go-difflib/difflib"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// maxPreviewDiffs is the number of objects whose diffs are shown in the confirmation prompt with --preview-diff.
+const maxPreviewDiffs = 3
+
+// applyPatchLocally applies the patch to the JSON encoded object in memory, the same way the API server would.
+// dataStruct is the typed object used to look up strategic merge keys; without it a JSON merge patch is applied,
+// as is done for custom resources.
+func applyPatchLocally(
+	original, patch []byte,
+	patchType k8s_types.PatchType,
+	dataStruct interface{},
+) ([]byte, error) {
+	switch patchType {
+	case k8s_types.JSONPatchType:
+		decoded, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json patch: %w", err)
+		}
+		return decoded.Apply(original)
+	case k8s_types.StrategicMergePatchType:
+		if dataStruct != nil {
+			return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+		}
+		return jsonpatch.MergePatch(original, patch)
+	case k8s_types.MergePatchType:
+		return jsonpatch.MergePatch(original, patch)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+}
+
+// printPatchDiff writes a unified diff of the object before and after applying the patch locally.
+func printPatchDiff(
+	out io.Writer,
+	name string,
+	original, patch []byte,
+	patchType k8s_types.PatchType,
+	dataStruct interface{},
+) error {
+	patched, err := applyPatchLocally(original, patch, patchType, dataStruct)
+	if err != nil {
+		return fmt.Errorf("failed to apply patch to %s: %w", name, err)
+	}
+
+	before, err := yaml.JSONToYAML(original)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to yaml: %w", name, err)
+	}
+	after, err := yaml.JSONToYAML(patched)
+	if err != nil {
+		return fmt.Errorf("failed to convert patched %s to yaml: %w", name, err)
+	}
+
+	return difflib.WriteUnifiedDiff(out, difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: name,
+		ToFile:   name + " (patched)",
+		Context:  diffContextLines,
+	})
+}
+
+// printPreviewDiffs writes diffs of the first maxPreviewDiffs of count objects for the confirmation prompt,
+// so the effect of the patch is seen before approving it without flooding the terminal.
+func printPreviewDiffs(out io.Writer, count int, printDiff func(i int, out io.Writer) error) error {
+	shown := min(count, maxPreviewDiffs)
+	for i := range shown {
+		if err := printDiff(i, out); err != nil {
+			return err
+		}
+	}
+	if count > shown {
+		if _, err := fmt.Fprintf(out, "... and %d more not shown\n", count-shown); err != nil {
+			return fmt.Errorf("failed to write to error output: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_printPatchDiff_AddLabel(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web"},
+		},
+	}
+	original, err := json.Marshal(pod)
+	require.NoError(t, err)
+
+	patch := []byte(`{"metadata":{"labels":{"team":"payments"}}}`)
+	for _, patchType := range []k8s_types.PatchType{k8s_types.StrategicMergePatchType, k8s_types.MergePatchType} {
+		t.Run(string(patchType), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, printPatchDiff(out, "pod/web-1", original, patch, patchType, &v1.Pod{}))
+
+			assert.Equal(t, "--- pod/web-1\n"+
+				"+++ pod/web-1 (patched)\n"+
+				"@@ -1,6 +1,7 @@\n"+
+				" metadata:\n"+
+				"   labels:\n"+
+				"     app: web\n"+
+				"+    team: payments\n"+
+				"   name: web-1\n"+
+				"   namespace: default\n"+
+				" spec:\n", out.String())
+		})
+	}
+}
+
+func Test_applyPatchLocally_JSONPatch(t *testing.T) {
+	original := []byte(`{"metadata":{"name":"cm","labels":{"app":"web"}}}`)
+	patch := []byte(`[{"op":"remove","path":"/metadata/labels/app"}]`)
+
+	patched, err := applyPatchLocally(original, patch, k8s_types.JSONPatchType, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"metadata":{"name":"cm","labels":{}}}`, string(patched))
+
+	_, err = applyPatchLocally(original, []byte(`{}`), k8s_types.JSONPatchType, nil)
+	require.Error(t, err)
+}
+
+func TestUniversalHandler_PreviewDiff(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	var objects []runtime.Object
+	for _, name := range []string{"web-1", "web-2", "web-3", "web-4"} {
+		objects = append(objects, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme, objects...)
+	handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+
+	streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+	in.WriteString("n\n")
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:    "default",
+		Action:       ActionPatch,
+		Patch:        `{"metadata":{"labels":{"team":"payments"}}}`,
+		PreviewDiff:  true,
+		ResourceType: configMapType,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, out.String(), "diffs are part of the confirmation prompt")
+	prompt := errOut.String()
+	assert.Contains(t, prompt, "The following configmaps will be patched:\n")
+	assert.Contains(t, prompt, "--- configmap/web-1\n+++ configmap/web-1 (patched)\n")
+	assert.Contains(t, prompt, "+    team: payments\n")
+	assert.Contains(t, prompt, "+++ configmap/web-3 (patched)\n")
+	assert.NotContains(t, prompt, "configmap/web-4 (patched)", "diffs are bounded to the first few resources")
+	assert.Contains(t, prompt, "... and 1 more not shown\nAre you sure you want to continue? [y/N]: Patch cancelled.\n")
+
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "patch", action.GetVerb(), "nothing is patched when the preview is declined")
+	}
+}
+
+func TestPodHandler_PreviewDiff(t *testing.T) {
+	clientSet := fake.NewClientset(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "web-1",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+	}})
+	handler := &PodHandler{clientSet: clientSet}
+
+	streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+	in.WriteString("y\n")
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionPatch,
+		Patch:       `{"metadata":{"labels":{"team":"payments"}}}`,
+		PreviewDiff: true,
+		Streams:     &streams,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "The following pods will be patched:\n"+
+		"- web-1 in namespace default\n"+
+		"--- pod/web-1\n"+
+		"+++ pod/web-1 (patched)\n"+
+		"@@ -1,6 +1,7 @@\n"+
+		" metadata:\n"+
+		"   labels:\n"+
+		"     app: web\n"+
+		"+    team: payments\n"+
+		"   name: web-1\n"+
+		"   namespace: default\n"+
+		" spec:\n"+
+		"Are you sure you want to continue? [y/N]: ", errOut.String())
+	assert.Equal(t, "Patched pod web-1 in namespace default\n1 of 1 pods changed, 0 already in the target state\n", out.String())
+
+	pod, err := clientSet.CoreV1().Pods("default").Get(t.Context(), "web-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "web", "team": "payments"}, pod.Labels)
+}
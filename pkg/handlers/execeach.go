@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/alikhil/kubectl-find/pkg/prompts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CommandTemplate is a local command given to --exec-each, one Go template per argument.
+// The command line is split into arguments before rendering, so values of the object always stay
+// within their argument and are never interpreted by a shell.
+type CommandTemplate []*template.Template
+
+// ParseCommandTemplate splits the command line into arguments like a shell does, honoring single
+// and double quotes and backslash escapes, and parses every argument as a Go template.
+// Template actions are kept intact, e.g. {{ index .metadata.labels "app" }} is a single argument.
+func ParseCommandTemplate(command string) (CommandTemplate, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	templates := make(CommandTemplate, len(args))
+	for i, arg := range args {
+		if templates[i], err = template.New("exec-each").Option("missingkey=error").Parse(arg); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// splitCommandLine splits the command line into arguments, removing quotes and escapes outside template actions.
+func splitCommandLine(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '{' && i+1 < len(runes) && runes[i+1] == '{':
+			end := strings.Index(string(runes[i:]), "}}")
+			if end < 0 {
+				return nil, errors.New("unterminated template action")
+			}
+			action := string(runes[i:])[:end+2]
+			arg.WriteString(action)
+			i += len([]rune(action)) - 1
+			inArg = true
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\\' && (quote == 0 || quote == '"' && i+1 < len(runes) && strings.ContainsRune(`"\`, runes[i+1])):
+			// outside quotes a backslash escapes any character, in double quotes only quotes and backslashes
+			if i+1 == len(runes) {
+				return nil, errors.New("unterminated escape at the end of the command")
+			}
+			i++
+			arg.WriteRune(runes[i])
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// Render executes the argument templates against the object content.
+func (c CommandTemplate) Render(data map[string]interface{}) ([]string, error) {
+	args := make([]string, len(c))
+	for i, arg := range c {
+		var buf bytes.Buffer
+		if err := arg.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		args[i] = buf.String()
+	}
+	if args[0] == "" {
+		return nil, errors.New("command renders to an empty program name")
+	}
+	return args, nil
+}
+
+// formatCommand returns the arguments as a command line to show, quoting arguments that are empty
+// or contain whitespace or quotes, so the boundaries of arguments are visible.
+func formatCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		needsQuotes := strings.ContainsFunc(arg, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '"' || r == '\''
+		})
+		if arg == "" || needsQuotes {
+			quoted[i] = strconv.Quote(arg)
+		} else {
+			quoted[i] = arg
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// execEach runs the local command rendered from --exec-each for every matched object, without a shell.
+// Commands of all objects are rendered and shown for confirmation before the first one is run.
+// A failing command does not stop the others, failures are counted and reported at the end.
+func execEach(ctx context.Context, options ActionOptions, items []unstructured.Unstructured, pluralName string) error {
+	if len(options.ExecEach) == 0 {
+		return errors.New("command template is required for exec-each action")
+	}
+	commands := make([][]string, len(items))
+	for i, item := range items {
+		args, err := options.ExecEach.Render(item.Object)
+		if err != nil {
+			return fmt.Errorf("failed to render command for %s: %w", item.GetName(), err)
+		}
+		commands[i] = args
+	}
+
+	if !options.SkipConfirm {
+		fmt.Fprintf(options.Streams.ErrOut, "The following commands will be run for %d %s:\n", len(items), pluralName)
+		for _, command := range commands {
+			fmt.Fprintf(options.Streams.ErrOut, "- %s\n", formatCommand(command))
+		}
+		if !prompts.AskForConfirmation(options.Streams) {
+			_, err := options.Streams.ErrOut.Write([]byte("Execution cancelled.\n"))
+			if err != nil {
+				return fmt.Errorf("failed to write to error output: %w", err)
+			}
+			return nil
+		}
+	}
+
+	failed := 0
+	defer options.Profiler.Start(ProgressPhaseExecuting)(len(items))
+	for i, command := range commands {
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdout = options.Streams.Out
+		cmd.Stderr = options.Streams.ErrOut
+		err := cmd.Run()
+		options.auditDone(&items[i], err)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			fmt.Fprintf(options.Streams.ErrOut, "Command for %s failed: %v\n", items[i].GetName(), err)
+		}
+		options.reportProgress(ProgressEvent{Phase: ProgressPhaseExecuting, Done: i + 1, Total: len(items)})
+	}
+	if failed > 0 {
+		return fmt.Errorf("command failed for %d of %d %s", failed, len(items), pluralName)
+	}
+	return nil
+}
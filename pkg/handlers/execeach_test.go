@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var configMapType = Resource{
+	GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+	GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+	SingularName:         "configmap",
+	PluralName:           "configmaps",
+	IsNamespaced:         true,
+}
+
+func newConfigMapHandler(t *testing.T) *UniversalHandler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"}},
+	)
+	return &UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+}
+
+func mustParseCommand(t *testing.T, command string) CommandTemplate {
+	t.Helper()
+	templates, err := ParseCommandTemplate(command)
+	require.NoError(t, err)
+	return templates
+}
+
+func TestParseCommandTemplate(t *testing.T) {
+	content := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "web",
+			"labels": map[string]interface{}{"app": "web server"},
+		},
+	}
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "arguments split on whitespace",
+			command: "kubectl  logs {{ .metadata.name }}",
+			want:    []string{"kubectl", "logs", "web"},
+		},
+		{
+			name:    "actions with spaces are one argument",
+			command: `echo {{ index .metadata.labels "app" }}`,
+			want:    []string{"echo", "web server"},
+		},
+		{
+			name:    "quotes and escapes",
+			command: `echo 'a b' "c \"d\"" e\ f pod-{{ .metadata.name }}.log`,
+			want:    []string{"echo", "a b", `c "d"`, "e f", "pod-web.log"},
+		},
+		{
+			name:    "actions in quotes",
+			command: `sh -c 'echo "$1"' _ "{{ .metadata.name }}"`,
+			want:    []string{"sh", "-c", `echo "$1"`, "_", "web"},
+		},
+		{
+			name:    "unterminated quote",
+			command: `echo 'web`,
+			wantErr: "unterminated ' quote",
+		},
+		{
+			name:    "unterminated action",
+			command: `echo {{ .metadata.name`,
+			wantErr: "unterminated template action",
+		},
+		{
+			name:    "empty command",
+			command: "  ",
+			wantErr: "command is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := ParseCommandTemplate(tt.command)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			args, err := templates.Render(content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
+
+func TestPodHandler_ExecEachDoesNotUseShell(t *testing.T) {
+	handler := PodHandler{clientSet: fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Namespace:   "default",
+			Annotations: map[string]string{"note": "$(echo injected); echo injected"},
+		}},
+	)}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionExecEach,
+		ExecEach:    mustParseCommand(t, `echo {{ index .metadata.annotations "note" }}`),
+		SkipConfirm: true,
+		Streams:     &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "$(echo injected); echo injected\n", out.String())
+}
+
+func TestUniversalHandler_ExecEach(t *testing.T) {
+	handler := newConfigMapHandler(t)
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:       ActionExecEach,
+		ExecEach:     mustParseCommand(t, "echo {{ .metadata.namespace }}/{{ .metadata.name }}"),
+		SkipConfirm:  true,
+		ResourceType: configMapType,
+		NaturalSort:  true,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "default/api\nstaging/web\n", out.String())
+}
+
+func TestUniversalHandler_ExecEachConfirmation(t *testing.T) {
+	handler := newConfigMapHandler(t)
+	streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+	in.WriteString("n\n")
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:       ActionExecEach,
+		ExecEach:     mustParseCommand(t, "echo {{ .metadata.name }}"),
+		ResourceType: configMapType,
+		NaturalSort:  true,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, out.String(), "no command must run without confirmation")
+	assert.Equal(t, "The following commands will be run for 2 configmaps:\n- echo api\n- echo web\n"+
+		"Are you sure you want to continue? [y/N]: Execution cancelled.\n", errOut.String())
+}
+
+func TestUniversalHandler_ExecEachFailure(t *testing.T) {
+	handler := newConfigMapHandler(t)
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action: ActionExecEach,
+		// object fields are passed to the script as arguments, never as part of the script
+		ExecEach: mustParseCommand(t,
+			`sh -c 'echo "$0"; exit {{ if eq .metadata.name "api" }}3{{ else }}0{{ end }}' {{ .metadata.name }}`),
+		SkipConfirm:  true,
+		ResourceType: configMapType,
+		NaturalSort:  true,
+		Streams:      &streams,
+	})
+	require.EqualError(t, err, "command failed for 1 of 2 configmaps")
+	assert.Equal(t, "api\nweb\n", out.String(), "a failing command does not stop the others")
+	assert.Equal(t, "Command for api failed: exit status 3\n", errOut.String())
+}
+
+func TestUniversalHandler_ExecEachTemplateError(t *testing.T) {
+	handler := newConfigMapHandler(t)
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:       ActionExecEach,
+		ExecEach:     mustParseCommand(t, "echo {{ .spec.missing }}"),
+		SkipConfirm:  true,
+		ResourceType: configMapType,
+		Streams:      &streams,
+	})
+	require.ErrorContains(t, err, "failed to render command for")
+	assert.Empty(t, out.String(), "no command must run when any of them cannot be rendered")
+}
+
+func TestPodHandler_ExecEach(t *testing.T) {
+	handler := PodHandler{clientSet: fake.NewClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "worker-1"},
+		},
+	)}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionExecEach,
+		ExecEach:    mustParseCommand(t, "echo {{ .metadata.name }} on {{ .spec.nodeName }}"),
+		SkipConfirm: true,
+		Streams:     &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "web-1 on worker-1", strings.TrimSpace(out.String()))
+}
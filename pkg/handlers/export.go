@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// cleanObject returns a copy of the object without server-populated fields,
+// so that it can be applied again to re-create the resource.
+func cleanObject(obj unstructured.Unstructured, gvk schema.GroupVersionKind) unstructured.Unstructured {
+	cleaned := *obj.DeepCopy()
+	if cleaned.GetKind() == "" {
+		// typed objects like pods come without type information
+		cleaned.SetGroupVersionKind(gvk)
+	}
+	for _, field := range []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"deletionGracePeriodSeconds",
+		"selfLink",
+		"managedFields",
+	} {
+		unstructured.RemoveNestedField(cleaned.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(cleaned.Object, "metadata", "annotations", lastAppliedConfigAnnotation)
+	if len(cleaned.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(cleaned.Object, "metadata", "annotations")
+	}
+	unstructured.RemoveNestedField(cleaned.Object, "status")
+	return cleaned
+}
+
+// exportFileName returns the file name for the object in namespace_kind_name.yaml format,
+// cluster-scoped objects are saved as kind_name.yaml.
+func exportFileName(obj unstructured.Unstructured) string {
+	parts := []string{strings.ToLower(obj.GetKind()), obj.GetName()}
+	if obj.GetNamespace() != "" {
+		parts = append([]string{obj.GetNamespace()}, parts...)
+	}
+	return strings.Join(parts, "_") + ".yaml"
+}
+
+// saveObjects writes each object as a cleaned YAML manifest into dir, creating it if needed.
+func saveObjects(dir string, objects []unstructured.Unstructured, gvk schema.GroupVersionKind) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for _, obj := range objects {
+		cleaned := cleanObject(obj, gvk)
+		data, err := yaml.Marshal(cleaned.Object)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", cleaned.GetKind(), cleaned.GetName(), err)
+		}
+		path := filepath.Join(dir, exportFileName(cleaned))
+		if err = os.WriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_saveObjects(t *testing.T) {
+	pod := toUnstructured(t, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web-1",
+			Namespace:         "default",
+			UID:               "uid-1",
+			ResourceVersion:   "42",
+			CreationTimestamp: metav1.Now(),
+			Labels:            map[string]string{"app": "web"},
+			Annotations:       map[string]string{lastAppliedConfigAnnotation: "{}"},
+			ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "nginx:1.27"}}},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	})
+	node := toUnstructured(t, &v1.Node{
+		TypeMeta:   metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "uid-2"},
+	})
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	err := saveObjects(dir, []unstructured.Unstructured{pod, node}, v1.SchemeGroupVersion.WithKind("Pod"))
+	require.NoError(t, err)
+
+	podManifest, err := os.ReadFile(filepath.Join(dir, "default_pod_web-1.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    app: web
+  name: web-1
+  namespace: default
+spec:
+  containers:
+  - image: nginx:1.27
+    name: app
+    resources: {}
+`, string(podManifest))
+
+	nodeManifest, err := os.ReadFile(filepath.Join(dir, "node_node-1.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Node
+metadata:
+  name: node-1
+spec: {}
+`, string(nodeManifest))
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+//nolint:gochecknoglobals
+var podResource = Resource{
+	GroupVersionResource: PodType,
+	GroupVersionKind:     v1.SchemeGroupVersion.WithKind("Pod"),
+	SingularName:         "pod",
+	PluralName:           "pods",
+	IsNamespaced:         true,
+}
+
+// workloadPods expands matched workloads into the pods selected by their spec.selector for --flatten-to-pods.
+type workloadPods struct {
+	clientSet kubernetes.Interface
+	printer   printers.BatchPrinter
+}
+
+// print prints the pods of all workloads in one table, pods selected by several workloads are printed once.
+func (w *workloadPods) print(ctx context.Context, workloads []unstructured.Unstructured, out io.Writer) error {
+	var pods []v1.Pod
+	for _, workload := range workloads {
+		selector, err := workloadSelector(workload)
+		if err != nil {
+			return fmt.Errorf("invalid selector of %s %s: %w", workload.GetKind(), workload.GetName(), err)
+		}
+		list, err := w.clientSet.CoreV1().Pods(workload.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("failed to list pods of %s %s: %w", workload.GetKind(), workload.GetName(), err)
+		}
+		pods = append(pods, list.Items...)
+	}
+	pods = uniqueByUID(pods, podUID)
+	if len(pods) == 0 {
+		return nil
+	}
+	podPointers := make([]*v1.Pod, len(pods))
+	for i := range pods {
+		podPointers[i] = &pods[i]
+	}
+	unstructuredPods, err := podsToUnstructured(podPointers)
+	if err != nil {
+		return err
+	}
+	return w.printer.PrintObjects(ctx, unstructuredPods, out)
+}
+
+// workloadSelector returns the label selector of the workload's pods from spec.selector.
+// Workloads always select their pods, so an empty selector, which would select every pod, is an error.
+func workloadSelector(workload unstructured.Unstructured) (string, error) {
+	content, found, err := unstructured.NestedMap(workload.Object, "spec", "selector")
+	if err != nil || !found {
+		return "", errors.New("spec.selector is missing")
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(content, labelSelector); err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", err
+	}
+	if selector.Empty() {
+		return "", errors.New("spec.selector is empty")
+	}
+	return selector.String(), nil
+}
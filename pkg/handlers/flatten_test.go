@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func labeledPod(name, namespace, app string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		UID:       types.UID(namespace + "/" + name),
+		Labels:    map[string]string{"app": app},
+	}}
+}
+
+func selectingDeployment(name, app string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		},
+	}
+}
+
+func TestUniversalHandler_FlattenToPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme,
+		selectingDeployment("web", "web"),
+		selectingDeployment("web-canary", "web"),
+		selectingDeployment("api", "api"),
+	)
+	clientSet := fake.NewClientset(
+		labeledPod("web-1", "default", "web"),
+		labeledPod("web-2", "default", "web"),
+		labeledPod("api-1", "default", "api"),
+		labeledPod("web-1", "staging", "web"),
+	)
+
+	deploymentType := Resource{
+		GroupVersionResource: DeploymentType,
+		GroupVersionKind:     appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		SingularName:         "deployment",
+		PluralName:           "deployments",
+		IsNamespaced:         true,
+	}
+	handler, err := GetResourceHandler(deploymentType, NewHandlerOptions().
+		WithClientSet(clientSet).
+		WithDynamic(dynamicClient).
+		WithNameOutput(true).
+		WithFlattenToPods(true))
+	require.NoError(t, err)
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err = handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:    "default",
+		Action:       ActionList,
+		NameRegex:    regexp.MustCompile("^web"),
+		ResourceType: deploymentType,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1\npod/web-2\n", out.String(),
+		"pods of both web deployments are printed once, pods of api and other namespaces are not")
+}
+
+func TestWorkloadSelector(t *testing.T) {
+	deployment := selectingDeployment("web", "web")
+	deployment.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+		{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}},
+	}
+	selector, err := workloadSelector(toUnstructured(t, deployment))
+	require.NoError(t, err)
+	assert.Equal(t, "app=web,tier in (frontend)", selector)
+
+	deployment.Spec.Selector = &metav1.LabelSelector{}
+	_, err = workloadSelector(toUnstructured(t, deployment))
+	require.EqualError(t, err, "spec.selector is empty")
+
+	deployment.Spec.Selector = nil
+	_, err = workloadSelector(toUnstructured(t, deployment))
+	require.EqualError(t, err, "spec.selector is missing")
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// listFunc lists objects in options.Namespace, returning them with the resource version of the list.
+type listFunc[T any] func(ctx context.Context, options ActionOptions) ([]T, string, error)
+
+// searchesForbiddenNamespaces returns true if listing in all namespaces failed with err because of RBAC,
+// and namespaces should be listed one by one, skipping the forbidden ones.
+// Watching needs a single list across all namespaces, so it is never retried.
+func searchesForbiddenNamespaces(options ActionOptions, namespaced bool, err error) bool {
+	return options.IgnoreForbidden && namespaced && options.Namespace == "" && !options.Watch && apierrors.IsForbidden(err)
+}
+
+// listAccessibleNamespaces lists objects namespace by namespace after listing in all namespaces was forbidden.
+// Namespaces the user cannot list objects in are skipped with a warning.
+// If listing namespaces is forbidden too, only options.FallbackNamespace is searched;
+// without it, or if that fails too, the original forbidden error is returned.
+func listAccessibleNamespaces[T any](
+	ctx context.Context,
+	options ActionOptions,
+	listNamespaces func(ctx context.Context) ([]string, error),
+	list listFunc[T],
+	forbidden error,
+) ([]T, error) {
+	namespaces, err := listNamespaces(ctx)
+	switch {
+	case err == nil:
+		fmt.Fprintln(options.Streams.ErrOut,
+			"Warning: listing in all namespaces is forbidden, searching namespaces one by one")
+	case apierrors.IsForbidden(err) && options.FallbackNamespace != "":
+		fmt.Fprintf(options.Streams.ErrOut,
+			"Warning: listing in all namespaces and listing namespaces are forbidden, "+
+				"searching only namespace %q and skipping all others\n", options.FallbackNamespace)
+		namespaceOptions := options
+		namespaceOptions.Namespace = options.FallbackNamespace
+		items, _, listErr := list(ctx, namespaceOptions)
+		if listErr != nil {
+			return nil, forbidden
+		}
+		return items, nil
+	default:
+		return nil, forbidden
+	}
+
+	var all []T
+	for _, namespace := range namespaces {
+		namespaceOptions := options
+		namespaceOptions.Namespace = namespace
+		items, _, listErr := list(ctx, namespaceOptions)
+		if apierrors.IsForbidden(listErr) {
+			fmt.Fprintf(options.Streams.ErrOut, "Warning: skipping forbidden namespace %q\n", namespace)
+			continue
+		}
+		if listErr != nil {
+			return nil, listErr
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// namespaceNames lists the names of all namespaces with the dynamic client.
+func (h *UniversalHandler) namespaceNames(ctx context.Context) ([]string, error) {
+	list, err := h.opts.Client.Resource(v1.SchemeGroupVersion.WithResource("namespaces")).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(list.Items))
+	for i, namespace := range list.Items {
+		names[i] = namespace.GetName()
+	}
+	return names, nil
+}
+
+// listInNamespace lists the resources in options.Namespace.
+func (h *UniversalHandler) listInNamespace(
+	ctx context.Context,
+	options ActionOptions,
+) ([]unstructured.Unstructured, string, error) {
+	resources := h.opts.Client.Resource(h.opts.Resource.GroupVersionResource).Namespace(options.Namespace)
+	return h.getResources(ctx, resources, options)
+}
+
+// namespaceNames lists the names of all namespaces.
+func (p *PodHandler) namespaceNames(ctx context.Context) ([]string, error) {
+	list, err := p.clientSet.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(list.Items))
+	for i, namespace := range list.Items {
+		names[i] = namespace.Name
+	}
+	return names, nil
+}
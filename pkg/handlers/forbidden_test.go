@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// forbidListing makes listing the resource in all namespaces and in the restricted namespace forbidden.
+func forbidListing(resource string) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "" || action.GetNamespace() == "restricted" {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", nil)
+		}
+		return false, nil, nil
+	}
+}
+
+func namespaceObjects() []runtime.Object {
+	var objects []runtime.Object
+	for _, name := range []string{"default", "restricted", "team-a"} {
+		objects = append(objects, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return objects
+}
+
+func TestPodHandler_IgnoreForbidden(t *testing.T) {
+	newClientSet := func() *fake.Clientset {
+		clientSet := fake.NewClientset(append(namespaceObjects(),
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "secret-1", Namespace: "restricted"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "team-a"}},
+		)...)
+		clientSet.PrependReactor("list", "pods", forbidListing("pods"))
+		return clientSet
+	}
+
+	t.Run("skips forbidden namespaces", func(t *testing.T) {
+		handler := PodHandler{clientSet: newClientSet(), nameOutput: true}
+		streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Action:          ActionList,
+			IgnoreForbidden: true,
+			NaturalSort:     true,
+			Streams:         &streams,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pod/api-1\npod/web-1\n", out.String())
+		assert.Equal(t, "Warning: listing in all namespaces is forbidden, searching namespaces one by one\n"+
+			"Warning: skipping forbidden namespace \"restricted\"\n", errOut.String())
+	})
+
+	t.Run("fails without ignore forbidden", func(t *testing.T) {
+		handler := PodHandler{clientSet: newClientSet(), nameOutput: true}
+		streams, _, _, _ := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{Action: ActionList, Streams: &streams})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsForbidden(err))
+	})
+
+	t.Run("fails when namespaces cannot be listed", func(t *testing.T) {
+		clientSet := newClientSet()
+		clientSet.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+		})
+		handler := PodHandler{clientSet: clientSet, nameOutput: true}
+		streams, _, _, _ := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Action:          ActionList,
+			IgnoreForbidden: true,
+			Streams:         &streams,
+		})
+		require.ErrorContains(t, err, `pods is forbidden`)
+	})
+
+	t.Run("searches the fallback namespace when namespaces cannot be listed", func(t *testing.T) {
+		clientSet := newClientSet()
+		clientSet.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+		})
+		handler := PodHandler{clientSet: clientSet, nameOutput: true}
+		streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Action:            ActionList,
+			IgnoreForbidden:   true,
+			FallbackNamespace: "team-a",
+			Streams:           &streams,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "pod/api-1\n", out.String())
+		assert.Equal(t, "Warning: listing in all namespaces and listing namespaces are forbidden, "+
+			"searching only namespace \"team-a\" and skipping all others\n", errOut.String())
+
+		err = handler.HandleAction(t.Context(), ActionOptions{
+			Action:            ActionList,
+			IgnoreForbidden:   true,
+			FallbackNamespace: "restricted",
+			Streams:           &streams,
+		})
+		require.ErrorContains(t, err, `pods is forbidden`, "the original error is returned when the fallback fails too")
+	})
+}
+
+func TestUniversalHandler_IgnoreForbidden(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme, append(namespaceObjects(),
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "restricted"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "team-a"}},
+	)...)
+	client.PrependReactor("list", "configmaps", forbidListing("configmaps"))
+	handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:          ActionDelete,
+		IgnoreForbidden: true,
+		SkipConfirm:     true,
+		ResourceType:    configMapType,
+		Streams:         &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted configmap settings\nDeleted configmap settings\n", out.String())
+	assert.Contains(t, errOut.String(), "Warning: skipping forbidden namespace \"restricted\"\n")
+
+	var deletedFrom []string
+	for _, a := range client.Actions() {
+		if a.GetVerb() == "delete" {
+			deletedFrom = append(deletedFrom, a.GetNamespace())
+		}
+	}
+	assert.ElementsMatch(t, []string{"default", "team-a"}, deletedFrom)
+}
+
+// listNamespaces makes listing namespaces return the given list, e.g. one naming a namespace twice.
+func listNamespaces(list runtime.Object) k8stesting.ReactionFunc {
+	return func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, list, nil
+	}
+}
+
+func TestPodHandler_IgnoreForbiddenOverlapping(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-web-1"}},
+	)
+	clientSet.PrependReactor("list", "pods", forbidListing("pods"))
+	clientSet.PrependReactor("list", "namespaces", listNamespaces(&v1.NamespaceList{Items: []v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	}}))
+	handler := PodHandler{clientSet: clientSet, nameOutput: true}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:          ActionList,
+		IgnoreForbidden: true,
+		Streams:         &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1\n", out.String(), "a pod returned by overlapping lists is printed once")
+}
+
+func TestUniversalHandler_IgnoreForbiddenOverlapping(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default", UID: "uid-settings"}},
+	)
+	client.PrependReactor("list", "configmaps", forbidListing("configmaps"))
+	namespace := func() unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Namespace")
+		obj.SetName("default")
+		return obj
+	}
+	client.PrependReactor("list", "namespaces", listNamespaces(&unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "v1", "kind": "NamespaceList"},
+		Items:  []unstructured.Unstructured{namespace(), namespace()},
+	}))
+	handler := UniversalHandler{opts: UniversalHandlerOptions{Client: client, Resource: configMapType}}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Action:          ActionDelete,
+		IgnoreForbidden: true,
+		SkipConfirm:     true,
+		ResourceType:    configMapType,
+		Streams:         &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted configmap settings\n", out.String(),
+		"a config map returned by overlapping lists is deleted once")
+}
@@ -64,6 +64,17 @@ func getResource(resourceType string) Resource {
 			SingularName: "namespace",
 			IsNamespaced: false,
 		}
+	case "persistentvolumeclaim":
+		return Resource{
+			GroupVersionResource: schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "persistentvolumeclaims",
+			},
+			PluralName:   "persistentvolumeclaims",
+			SingularName: "persistentvolumeclaim",
+			IsNamespaced: true,
+		}
 	case "node":
 		return Resource{
 			GroupVersionResource: schema.GroupVersionResource{
@@ -75,6 +86,20 @@ func getResource(resourceType string) Resource {
 			SingularName: "node",
 			IsNamespaced: false,
 		}
+	case "deployment":
+		return Resource{
+			GroupVersionResource: DeploymentType,
+			PluralName:           "deployments",
+			SingularName:         "deployment",
+			IsNamespaced:         true,
+		}
+	case "daemonset":
+		return Resource{
+			GroupVersionResource: DaemonSetType,
+			PluralName:           "daemonsets",
+			SingularName:         "daemonset",
+			IsNamespaced:         true,
+		}
 	default:
 		return Resource{}
 	}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// labelHintListLimit bounds the unfiltered list done to collect label keys for --hint-on-empty.
+const labelHintListLimit = 500
+
+// needsLabelHint returns true if the label selector matched nothing and --hint-on-empty asks to explain it.
+func needsLabelHint(options ActionOptions, listed int) bool {
+	return options.HintOnEmpty && options.LabelSelector != "" && listed == 0
+}
+
+// printLabelKeysHint prints the distinct label keys of objects listed without the label selector,
+// so a typo in the selector can be told apart from a genuinely empty result.
+// Truncated is true if only the first labelHintListLimit objects were checked.
+func printLabelKeysHint(out io.Writer, options ActionOptions, pluralName string, objects []metav1.Object, truncated bool) {
+	scope := "all namespaces"
+	if options.Namespace != "" {
+		scope = fmt.Sprintf("namespace %q", options.Namespace)
+	}
+	if len(objects) == 0 {
+		fmt.Fprintf(out, "Hint: no %s match selector %q, there are no %s in %s at all\n",
+			pluralName, options.LabelSelector, pluralName, scope)
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, obj := range objects {
+		for key := range obj.GetLabels() {
+			seen[key] = true
+		}
+	}
+	if len(seen) == 0 {
+		fmt.Fprintf(out, "Hint: no %s match selector %q, %s in %s have no labels\n",
+			pluralName, options.LabelSelector, pluralName, scope)
+		return
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	checked := ""
+	if truncated {
+		checked = fmt.Sprintf(" (first %d checked)", len(objects))
+	}
+	fmt.Fprintf(out, "Hint: no %s match selector %q, label keys on %s in %s%s: %s\n",
+		pluralName, options.LabelSelector, pluralName, scope, checked, strings.Join(keys, ", "))
+}
+
+// hintLabelKeys lists resources without the label selector and prints their label keys to stderr.
+func (h *UniversalHandler) hintLabelKeys(ctx context.Context, options ActionOptions) {
+	list, err := h.opts.Client.Resource(h.opts.Resource.GroupVersionResource).
+		Namespace(options.Namespace).
+		List(ctx, metav1.ListOptions{Limit: labelHintListLimit})
+	if err != nil {
+		fmt.Fprintf(options.Streams.ErrOut, "Warning: failed to list %s for the label hint: %v\n", h.opts.Resource.PluralName, err)
+		return
+	}
+	objects := make([]metav1.Object, len(list.Items))
+	for i := range list.Items {
+		objects[i] = &list.Items[i]
+	}
+	printLabelKeysHint(options.Streams.ErrOut, options, h.opts.Resource.PluralName, objects, list.GetContinue() != "")
+}
+
+// hintLabelKeys lists pods without the label selector and prints their label keys to stderr.
+func (p *PodHandler) hintLabelKeys(ctx context.Context, options ActionOptions) {
+	list, err := p.clientSet.CoreV1().Pods(options.Namespace).List(ctx, metav1.ListOptions{Limit: labelHintListLimit})
+	if err != nil {
+		fmt.Fprintf(options.Streams.ErrOut, "Warning: failed to list pods for the label hint: %v\n", err)
+		return
+	}
+	objects := make([]metav1.Object, len(list.Items))
+	for i := range list.Items {
+		objects[i] = &list.Items[i]
+	}
+	printLabelKeysHint(options.Streams.ErrOut, options, "pods", objects, list.Continue != "")
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHandlers_HintOnEmpty(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	labeled := metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{
+		"app.kubernetes.io/name": "web",
+		"tier":                   "frontend",
+	}}
+	other := metav1.ObjectMeta{Name: "api-1", Namespace: "default", Labels: map[string]string{"app.kubernetes.io/name": "api"}}
+
+	tests := map[string]struct {
+		handler ResourceHandler
+		plural  string
+	}{
+		"pods": {
+			handler: &PodHandler{clientSet: fake.NewClientset(&v1.Pod{ObjectMeta: labeled}, &v1.Pod{ObjectMeta: other})},
+			plural:  "pods",
+		},
+		"configmaps": {
+			handler: &UniversalHandler{opts: UniversalHandlerOptions{
+				Client:   dynamicfake.NewSimpleDynamicClient(scheme, &v1.ConfigMap{ObjectMeta: labeled}, &v1.ConfigMap{ObjectMeta: other}),
+				Resource: configMapType,
+			}},
+			plural: "configmaps",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			list := func(selector string, hint bool) string {
+				streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+				err := tt.handler.HandleAction(t.Context(), ActionOptions{
+					Namespace:     "default",
+					Action:        ActionList,
+					LabelSelector: selector,
+					HintOnEmpty:   hint,
+					ResourceType:  configMapType,
+					Streams:       &streams,
+				})
+				require.NoError(t, err)
+				return errOut.String()
+			}
+
+			assert.Equal(t, `Hint: no `+tt.plural+` match selector "app=web", label keys on `+tt.plural+
+				` in namespace "default": app.kubernetes.io/name, tier`+"\n", list("app=web", true))
+			assert.Empty(t, list("app=web", false), "the hint is only printed when asked for")
+		})
+	}
+}
+
+func TestPrintLabelKeysHint(t *testing.T) {
+	options := ActionOptions{LabelSelector: "app=web"}
+	hint := func(objects []metav1.Object, truncated bool) string {
+		out := &bytes.Buffer{}
+		printLabelKeysHint(out, options, "secrets", objects, truncated)
+		return out.String()
+	}
+
+	assert.Equal(t, "Hint: no secrets match selector \"app=web\", there are no secrets in all namespaces at all\n",
+		hint(nil, false))
+	assert.Equal(t, "Hint: no secrets match selector \"app=web\", secrets in all namespaces have no labels\n",
+		hint([]metav1.Object{&metav1.ObjectMeta{Name: "token"}}, false))
+	assert.Equal(t, "Hint: no secrets match selector \"app=web\", label keys on secrets in all namespaces (first 1 checked): app\n",
+		hint([]metav1.Object{&metav1.ObjectMeta{Name: "token", Labels: map[string]string{"app": "api"}}}, true))
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	v1 "k8s.io/api/core/v1"
+)
+
+// imageUsage is a container image with the number of pods using it.
+type imageUsage struct {
+	image string
+	pods  int
+}
+
+// summarizeImages returns distinct container images of the pods, sorted by descending number
+// of pods using them. Each pod is counted once per image, including init containers.
+func summarizeImages(pods []*v1.Pod) []imageUsage {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		seen := map[string]bool{}
+		for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, container := range containers {
+				if !seen[container.Image] {
+					seen[container.Image] = true
+					counts[container.Image]++
+				}
+			}
+		}
+	}
+
+	usages := make([]imageUsage, 0, len(counts))
+	for image, count := range counts {
+		usages = append(usages, imageUsage{image: image, pods: count})
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].pods != usages[j].pods {
+			return usages[i].pods > usages[j].pods
+		}
+		return usages[i].image < usages[j].image
+	})
+	return usages
+}
+
+func printImageSummary(pods []*v1.Pod, out io.Writer) error {
+	usages := summarizeImages(pods)
+	data := make([][]string, len(usages))
+	for i, usage := range usages {
+		data[i] = []string{usage.image, strconv.Itoa(usage.pods)}
+	}
+	return printers.RenderTable(out, []string{"IMAGE", "PODS"}, data)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// JobStatusMatches is a ResourceMatcher that keeps only completed or failed jobs
+// when --completed or --failed-job is set.
+func JobStatusMatches(resource unstructured.Unstructured, options *ActionOptions) bool {
+	if !options.Completed && !options.FailedJob {
+		return true
+	}
+
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, job); err != nil {
+		return false
+	}
+	if options.Completed && !isJobCompleted(job) {
+		return false
+	}
+	if options.FailedJob && !hasJobCondition(job, batchv1.JobFailed) {
+		return false
+	}
+	return true
+}
+
+// isJobCompleted returns true if the job has the Complete condition
+// or as many succeeded pods as completions it requires.
+func isJobCompleted(job *batchv1.Job) bool {
+	if hasJobCondition(job, batchv1.JobComplete) {
+		return true
+	}
+	return job.Spec.Completions != nil && job.Status.Succeeded >= *job.Spec.Completions
+}
+
+func hasJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
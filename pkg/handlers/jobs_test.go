@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/alikhil/kubectl-find/pkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestJobStatusMatches(t *testing.T) {
+	three := int32(3)
+	condition := func(conditionType batchv1.JobConditionType) []batchv1.JobCondition {
+		return []batchv1.JobCondition{{Type: conditionType, Status: v1.ConditionTrue}}
+	}
+
+	tests := []struct {
+		name          string
+		spec          batchv1.JobSpec
+		status        batchv1.JobStatus
+		wantCompleted bool
+		wantFailed    bool
+	}{
+		{
+			name:          "complete condition",
+			status:        batchv1.JobStatus{Succeeded: 1, Conditions: condition(batchv1.JobComplete)},
+			wantCompleted: true,
+		},
+		{
+			name:          "all completions succeeded",
+			spec:          batchv1.JobSpec{Completions: &three},
+			status:        batchv1.JobStatus{Succeeded: 3},
+			wantCompleted: true,
+		},
+		{
+			name:   "some completions succeeded",
+			spec:   batchv1.JobSpec{Completions: &three},
+			status: batchv1.JobStatus{Succeeded: 2, Active: 1},
+		},
+		{
+			name:   "running",
+			status: batchv1.JobStatus{Active: 1},
+		},
+		{
+			name:       "failed",
+			status:     batchv1.JobStatus{Failed: 6, Conditions: condition(batchv1.JobFailed)},
+			wantFailed: true,
+		},
+		{
+			name: "suspended",
+			status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: v1.ConditionFalse},
+				{Type: batchv1.JobSuspended, Status: v1.ConditionTrue},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&batchv1.Job{Spec: tt.spec, Status: tt.status})
+			require.NoError(t, err)
+			job := unstructured.Unstructured{Object: obj}
+
+			assert.Equal(t, tt.wantCompleted, JobStatusMatches(job, &ActionOptions{Completed: true}), "completed")
+			assert.Equal(t, tt.wantFailed, JobStatusMatches(job, &ActionOptions{FailedJob: true}), "failed")
+			assert.True(t, JobStatusMatches(job, &ActionOptions{}), "without job filters every job matches")
+		})
+	}
+}
+
+func TestJobStatusMatches_WithJQ(t *testing.T) {
+	job := func(name string, status batchv1.JobStatus) unstructured.Unstructured {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&batchv1.Job{Status: status})
+		require.NoError(t, err)
+		job := unstructured.Unstructured{Object: obj}
+		job.SetName(name)
+		return job
+	}
+	query, err := pkg.PrepareQuery(`.metadata.name | startswith("nightly-")`)
+	require.NoError(t, err)
+	jobs := Resource{GroupVersionResource: JobType}
+	handler := &UniversalHandler{opts: UniversalHandlerOptions{
+		Resource:        jobs,
+		ResourceMatcher: getResourceMatcher(jobs),
+	}}
+	options := ActionOptions{Completed: true, JQQuery: query}
+	completed := batchv1.JobStatus{
+		Succeeded:  1,
+		Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}},
+	}
+
+	assert.True(t, handler.resourceMatches(job("nightly-backup", completed), &options), "completed and matching jq")
+	assert.False(t, handler.resourceMatches(job("nightly-report", batchv1.JobStatus{Active: 1}), &options),
+		"running job matching jq")
+	assert.False(t, handler.resourceMatches(job("hourly-backup", completed), &options), "completed but not matching jq")
+}
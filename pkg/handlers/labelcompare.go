@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// labelComparisonRegex matches a --label-compare expression, e.g. version>=3.
+// Two-character operators come first, so that >= is not parsed as > followed by "=3".
+var labelComparisonRegex = regexp.MustCompile(`^\s*([^<>=!\s]+)\s*(==|!=|>=|<=|>|<)\s*(\S+)\s*$`)
+
+// LabelComparison compares the value of a label as a number, e.g. version>3.
+type LabelComparison struct {
+	Key      string
+	Operator string
+	Value    float64
+}
+
+// ParseLabelComparison parses a --label-compare expression: KEY followed by one of ==, !=, >, >=, <, <= and a number.
+func ParseLabelComparison(expr string) (LabelComparison, error) {
+	match := labelComparisonRegex.FindStringSubmatch(expr)
+	if match == nil {
+		return LabelComparison{}, fmt.Errorf(
+			"invalid label comparison %q, expected KEY followed by one of ==, !=, >, >=, <, <= and a number (e.g. 'version>3')",
+			expr,
+		)
+	}
+	value, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return LabelComparison{}, fmt.Errorf("invalid label comparison %q: %q is not a number", expr, match[3])
+	}
+	return LabelComparison{Key: match[1], Operator: match[2], Value: value}, nil
+}
+
+// Matches returns true if the label is set to a number satisfying the comparison.
+// Objects without the label or with a non-numeric value never match.
+func (c LabelComparison) Matches(labels map[string]string) bool {
+	raw, ok := labels[c.Key]
+	if !ok {
+		return false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return false
+	}
+	switch c.Operator {
+	case "==":
+		return value == c.Value
+	case "!=":
+		return value != c.Value
+	case ">":
+		return value > c.Value
+	case ">=":
+		return value >= c.Value
+	case "<":
+		return value < c.Value
+	case "<=":
+		return value <= c.Value
+	default:
+		return false
+	}
+}
+
+// labelComparisonsMatch returns true if the labels satisfy all comparisons.
+func labelComparisonsMatch(labels map[string]string, comparisons []LabelComparison) bool {
+	for _, comparison := range comparisons {
+		if !comparison.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseLabelComparison(t *testing.T) {
+	comparison, err := ParseLabelComparison("version>=3")
+	require.NoError(t, err)
+	assert.Equal(t, LabelComparison{Key: "version", Operator: ">=", Value: 3}, comparison)
+
+	comparison, err = ParseLabelComparison(" app.kubernetes.io/revision == 1.5 ")
+	require.NoError(t, err)
+	assert.Equal(t, LabelComparison{Key: "app.kubernetes.io/revision", Operator: "==", Value: 1.5}, comparison)
+
+	_, err = ParseLabelComparison("version=3")
+	require.ErrorContains(t, err, `invalid label comparison "version=3"`)
+	_, err = ParseLabelComparison("version>three")
+	require.EqualError(t, err, `invalid label comparison "version>three": "three" is not a number`)
+}
+
+func TestLabelComparison_Matches(t *testing.T) {
+	labels := map[string]string{"version": "4", "app": "web"}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "version>3", want: true},
+		{expr: "version>4", want: false},
+		{expr: "version>=4", want: true},
+		{expr: "version<10", want: true},
+		{expr: "version<4", want: false},
+		{expr: "version<=3.5", want: false},
+		{expr: "version==4", want: true},
+		{expr: "version==4.0", want: true},
+		{expr: "version!=4", want: false},
+		{expr: "app>0", want: false},
+		{expr: "missing<100", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			comparison, err := ParseLabelComparison(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, comparison.Matches(labels))
+		})
+	}
+}
+
+func TestLabelCompare_Matchers(t *testing.T) {
+	newer, err := ParseLabelComparison("version>3")
+	require.NoError(t, err)
+	older, err := ParseLabelComparison("version<10")
+	require.NoError(t, err)
+	options := ActionOptions{LabelCompare: []LabelComparison{newer, older}}
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"version": "12"}}}
+	assert.False(t, (&PodHandler{}).getMatcher(options)(pod))
+	pod.Labels["version"] = "5"
+	assert.True(t, (&PodHandler{}).getMatcher(options)(pod))
+
+	configMap := unstructured.Unstructured{}
+	configMap.SetLabels(map[string]string{"version": "2"})
+	handler := &UniversalHandler{}
+	assert.False(t, handler.resourceMatches(configMap, &options))
+	configMap.SetLabels(map[string]string{"version": "7"})
+	assert.True(t, handler.resourceMatches(configMap, &options))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParseListOptions parses the JSON given by --list-options, e.g. {"limit": 100, "timeoutSeconds": 30}.
+// Fields turning the list into a watch or resuming another list are rejected,
+// because listing pages and watching for changes rely on setting them.
+func ParseListOptions(data string) (metav1.ListOptions, error) {
+	var options metav1.ListOptions
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&options); err != nil {
+		return metav1.ListOptions{}, fmt.Errorf("failed to parse list options: %w", err)
+	}
+	if decoder.More() {
+		return metav1.ListOptions{}, errors.New("failed to parse list options: unexpected data after the JSON object")
+	}
+
+	switch {
+	case options.Watch:
+		return metav1.ListOptions{}, errors.New("watch cannot be set in list options, use --watch instead")
+	case options.AllowWatchBookmarks || options.SendInitialEvents != nil:
+		return metav1.ListOptions{}, errors.New("watch related fields cannot be set in list options")
+	case options.Continue != "":
+		return metav1.ListOptions{}, errors.New("continue cannot be set in list options, all pages are always listed")
+	}
+	return options, nil
+}
+
+// ListOptionsWithSelectors merges the selectors into the --list-options given by the user.
+// Selectors set with flags win.
+func ListOptionsWithSelectors(listOptions metav1.ListOptions, labelSelector, fieldSelector string) metav1.ListOptions {
+	if labelSelector != "" {
+		listOptions.LabelSelector = labelSelector
+	}
+	if fieldSelector != "" {
+		listOptions.FieldSelector = fieldSelector
+	}
+	return listOptions
+}
+
+// pageListOptions returns the options to list a page with, merging the computed selectors and
+// the continue token into the --list-options given by the user. Selectors set with flags win.
+// The resource version only applies to the first page, the API server rejects it with a continue token.
+func pageListOptions(options ActionOptions, fieldSelector, continueToken string) metav1.ListOptions {
+	listOptions := ListOptionsWithSelectors(options.ListOptions, options.LabelSelector, fieldSelector)
+	if continueToken != "" {
+		listOptions.Continue = continueToken
+		listOptions.ResourceVersion = ""
+		listOptions.ResourceVersionMatch = ""
+	}
+	return listOptions
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseListOptions(t *testing.T) {
+	options, err := ParseListOptions(`{"limit": 100, "timeoutSeconds": 30, "resourceVersion": "0", "labelSelector": "app=web"}`)
+	require.NoError(t, err)
+	timeout := int64(30)
+	assert.Equal(t, metav1.ListOptions{
+		Limit:           100,
+		TimeoutSeconds:  &timeout,
+		ResourceVersion: "0",
+		LabelSelector:   "app=web",
+	}, options)
+
+	for blob, wantErr := range map[string]string{
+		`{"limit": "many"}`:                "failed to parse list options",
+		`{"limt": 100}`:                    `unknown field "limt"`,
+		`{"limit": 100} {}`:                "unexpected data after the JSON object",
+		`{"watch": true}`:                  "watch cannot be set in list options",
+		`{"allowWatchBookmarks": true}`:    "watch related fields cannot be set in list options",
+		`{"sendInitialEvents": false}`:     "watch related fields cannot be set in list options",
+		`{"continue": "eyJ2IjoibWV0YSJ9"}`: "continue cannot be set in list options",
+	} {
+		_, err = ParseListOptions(blob)
+		assert.ErrorContains(t, err, wantErr, blob)
+	}
+}
+
+func TestPageListOptions(t *testing.T) {
+	options := ActionOptions{
+		LabelSelector: "app=web",
+		ListOptions: metav1.ListOptions{
+			LabelSelector:        "app=api",
+			FieldSelector:        "status.phase=Running",
+			ResourceVersion:      "0",
+			ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+			Limit:                50,
+		},
+	}
+
+	assert.Equal(t, metav1.ListOptions{
+		LabelSelector:        "app=web",
+		FieldSelector:        "status.phase=Running",
+		ResourceVersion:      "0",
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+		Limit:                50,
+	}, pageListOptions(options, "", ""), "selectors set with flags win over the list options")
+
+	assert.Equal(t, metav1.ListOptions{
+		LabelSelector: "app=web",
+		FieldSelector: "spec.nodeName=node-1",
+		Continue:      "next",
+		Limit:         50,
+	}, pageListOptions(options, "spec.nodeName=node-1", "next"), "resource version only applies to the first page")
+}
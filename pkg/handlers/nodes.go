@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"strings"
-
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -12,34 +10,5 @@ func NodeConditionMatches(resource unstructured.Unstructured, options *ActionOpt
 	if len(options.NodeConditions) == 0 {
 		return true
 	}
-
-	conditionsRaw, found, _ := unstructured.NestedSlice(resource.Object, "status", "conditions")
-	if !found {
-		return false
-	}
-
-	conditionMap := make(map[string]string, len(conditionsRaw))
-	for _, c := range conditionsRaw {
-		cMap, ok := c.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		cType, _ := cMap["type"].(string)
-		cStatus, _ := cMap["status"].(string)
-		if cType != "" {
-			conditionMap[strings.ToLower(cType)] = strings.ToLower(cStatus)
-		}
-	}
-
-	for _, nc := range options.NodeConditions {
-		actual, exists := conditionMap[strings.ToLower(nc.Type)]
-		if !exists {
-			return false
-		}
-		if actual != strings.ToLower(nc.Status) {
-			return false
-		}
-	}
-
-	return true
+	return conditionsMatch(resourceConditions(resource), options.NodeConditions)
 }
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// newOwnerGetter returns an OwnerGetter that fetches owners with the dynamic client.
+// Owner kinds are mapped to resources with the mapper; namespaced owners are looked up in the namespace
+// of the owned object, and owners must have the UID recorded in the owner reference.
+func newOwnerGetter(client dynamic.Interface, mapper meta.RESTMapper) printers.OwnerGetter {
+	return func(
+		ctx context.Context,
+		obj unstructured.Unstructured,
+		owner metav1.OwnerReference,
+	) (*unstructured.Unstructured, error) {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid owner api version %q: %w", owner.APIVersion, err)
+		}
+		mapping, err := mapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find resource of owner %s/%s: %w", owner.Kind, owner.Name, err)
+		}
+
+		var resource dynamic.ResourceInterface = client.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			resource = client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		}
+		res, err := resource.Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get owner %s/%s: %w", owner.Kind, owner.Name, err)
+		}
+
+		if res.GetUID() != owner.UID {
+			// an object of the same name replaced the owner, which is gone
+			return nil, fmt.Errorf("owner %s/%s has been replaced: %w", owner.Kind, owner.Name,
+				apierrors.NewNotFound(mapping.Resource.GroupResource(), owner.Name))
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func Test_newOwnerGetter(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta:   metav1.TypeMeta{Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", UID: "uid-rs"},
+	}
+	node := &v1.Node{
+		TypeMeta:   metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", UID: "uid-node"},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, v1.AddToScheme(scheme))
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"), meta.RESTScopeNamespace)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("Node"), meta.RESTScopeRoot)
+	getOwner := newOwnerGetter(dynamicfake.NewSimpleDynamicClient(scheme, replicaSet, node), mapper)
+
+	pod := toUnstructured(t, &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-abc-1", Namespace: "default"},
+	})
+	ref := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", UID: "uid-rs"}
+
+	owner, err := getOwner(t.Context(), pod, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "web-abc", owner.GetName())
+
+	// mirror pods are owned by their cluster-scoped node
+	nodeRef := metav1.OwnerReference{APIVersion: "v1", Kind: "Node", Name: "worker-1", UID: "uid-node"}
+	owner, err = getOwner(t.Context(), pod, nodeRef)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-1", owner.GetName())
+
+	ref.UID = "uid-old"
+	_, err = getOwner(t.Context(), pod, ref)
+	require.EqualError(t, err, `owner ReplicaSet/web-abc has been replaced: replicasets.apps "web-abc" not found`)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = getOwner(t.Context(), pod, metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Widget", Name: "w"})
+	require.ErrorContains(t, err, "unable to find resource of owner Widget/w")
+}
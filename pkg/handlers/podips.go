@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// podIPs returns the IP addresses assigned to the pod: all of status.podIPs on dual-stack clusters,
+// or status.podIP if the former is not set. Pods without an assigned IP, e.g. pending ones, have none.
+func podIPs(pod *v1.Pod) []net.IP {
+	var ips []net.IP
+	for _, podIP := range pod.Status.PodIPs {
+		if ip := net.ParseIP(podIP.IP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	if len(ips) == 0 {
+		if ip := net.ParseIP(pod.Status.PodIP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// hostIPs returns the IP addresses of the node the pod runs on, status.hostIPs or status.hostIP
+// like podIPs does. Pods that are not bound to a node yet have none.
+func hostIPs(pod *v1.Pod) []net.IP {
+	var ips []net.IP
+	for _, hostIP := range pod.Status.HostIPs {
+		if ip := net.ParseIP(hostIP.IP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	if len(ips) == 0 {
+		if ip := net.ParseIP(pod.Status.HostIP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// hasHostIP returns true if any IP address of the node the pod runs on is ip.
+func hasHostIP(pod *v1.Pod, ip net.IP) bool {
+	return slices.ContainsFunc(hostIPs(pod), ip.Equal)
+}
+
+// hasPodIP returns true if any IP address of the pod is ip.
+func hasPodIP(pod *v1.Pod, ip net.IP) bool {
+	return slices.ContainsFunc(podIPs(pod), ip.Equal)
+}
+
+// hasPodIPInRange returns true if any IP address of the pod is in the network.
+func hasPodIPInRange(pod *v1.Pod, network *net.IPNet) bool {
+	return slices.ContainsFunc(podIPs(pod), network.Contains)
+}
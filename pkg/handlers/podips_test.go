@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodMatcher_PodIP(t *testing.T) {
+	pod := func(ips ...string) *v1.Pod {
+		pod := &v1.Pod{}
+		if len(ips) > 0 {
+			pod.Status.PodIP = ips[0]
+		}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, v1.PodIP{IP: ip})
+		}
+		return pod
+	}
+	_, podNetwork, err := net.ParseCIDR("10.1.0.0/16")
+	require.NoError(t, err)
+	_, ipv6Network, err := net.ParseCIDR("fd00:10:1::/64")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		options ActionOptions
+		pod     *v1.Pod
+		want    bool
+	}{
+		{
+			name:    "exact IP",
+			options: ActionOptions{PodIP: net.ParseIP("10.1.4.23")},
+			pod:     pod("10.1.4.23"),
+			want:    true,
+		},
+		{
+			name:    "different IP",
+			options: ActionOptions{PodIP: net.ParseIP("10.1.4.23")},
+			pod:     pod("10.1.4.24"),
+			want:    false,
+		},
+		{
+			name:    "secondary IP of a dual-stack pod",
+			options: ActionOptions{PodIP: net.ParseIP("fd00:10:1::17")},
+			pod:     pod("10.1.4.23", "fd00:10:1::17"),
+			want:    true,
+		},
+		{
+			name:    "IP in range",
+			options: ActionOptions{PodIPRange: podNetwork},
+			pod:     pod("10.1.200.7"),
+			want:    true,
+		},
+		{
+			name:    "IP out of range",
+			options: ActionOptions{PodIPRange: podNetwork},
+			pod:     pod("10.2.0.7"),
+			want:    false,
+		},
+		{
+			name:    "IPv6 range of a dual-stack pod",
+			options: ActionOptions{PodIPRange: ipv6Network},
+			pod:     pod("10.2.0.7", "fd00:10:1::17"),
+			want:    true,
+		},
+		{
+			name:    "only status.podIP set",
+			options: ActionOptions{PodIPRange: podNetwork},
+			pod:     &v1.Pod{Status: v1.PodStatus{PodIP: "10.1.0.1"}},
+			want:    true,
+		},
+		{
+			name:    "pending pod without IP",
+			options: ActionOptions{PodIPRange: podNetwork},
+			pod:     pod(),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, (&PodHandler{}).getMatcher(tt.options)(tt.pod))
+		})
+	}
+}
+
+func TestPodMatcher_HostIP(t *testing.T) {
+	onHost := func(hostIP string, hostIPs ...string) *v1.Pod {
+		pod := &v1.Pod{Status: v1.PodStatus{HostIP: hostIP}}
+		for _, ip := range hostIPs {
+			pod.Status.HostIPs = append(pod.Status.HostIPs, v1.HostIP{IP: ip})
+		}
+		return pod
+	}
+	match := func(hostIP string, pod *v1.Pod) bool {
+		return (&PodHandler{}).getMatcher(ActionOptions{HostIP: net.ParseIP(hostIP)})(pod)
+	}
+
+	assert.True(t, match("192.168.10.14", onHost("192.168.10.14")))
+	assert.False(t, match("192.168.10.14", onHost("192.168.10.15")))
+	assert.True(t, match("fd00::14", onHost("192.168.10.14", "192.168.10.14", "fd00::14")))
+	assert.False(t, match("192.168.10.14", onHost("")), "pod not bound to a node")
+}
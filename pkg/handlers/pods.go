@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,16 +17,28 @@ import (
 	"github.com/alikhil/kubectl-find/pkg/printers"
 	"github.com/alikhil/kubectl-find/pkg/prompts"
 	"github.com/alikhil/kubectl-find/pkg/sortby"
+	"github.com/distribution/reference"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8s_types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
 )
 
+const dockerHubRegistry = "docker.io"
+
+// evictionRetryInterval is how long to wait before retrying an eviction blocked by a disruption budget.
+//
+//nolint:gochecknoglobals
+var evictionRetryInterval = 5 * time.Second
+
 //nolint:gochecknoglobals
 var ValidPodStatuses = []string{"Pending", "Running", "Succeeded", "Failed", "Unknown"}
 
@@ -59,67 +75,445 @@ type PodHandler struct {
 	clientSet      kubernetes.Interface
 	executorGetter ExecutorGetter
 	printer        printers.BatchPrinter
+	nameOutput     bool // print names straight from typed pods, skipping the unstructured conversion
 }
 
-func (p *PodHandler) getAllPods(ctx context.Context, options ActionOptions) ([]v1.Pod, error) {
+// getAllPods lists pods page by page; the returned resource version of the list can be used to watch changes.
+func (p *PodHandler) getAllPods(ctx context.Context, options ActionOptions) ([]v1.Pod, string, error) {
 	allPods := make([]v1.Pod, 0)
 	continueToken := ""
+	pages := 0
 	for {
 		pods, err := p.clientSet.CoreV1().
 			Pods(options.Namespace).
-			List(ctx, metav1.ListOptions{LabelSelector: options.LabelSelector, Continue: continueToken})
+			List(ctx, pageListOptions(options, podFieldSelector(options), continueToken))
 		if err != nil {
-			return nil, fmt.Errorf("failed to list pods: %w", err)
+			return nil, "", fmt.Errorf("failed to list pods: %w", err)
 		}
 		allPods = append(allPods, pods.Items...)
+		pages++
+		klog.V(3).InfoS("Listed page of pods", "namespace", options.Namespace, "page", pages,
+			"items", len(pods.Items), "total", len(allPods))
+		options.reportProgress(ProgressEvent{Phase: ProgressPhaseListing, PagesDone: pages, Listed: len(allPods)})
 		continueToken = pods.Continue
 		if continueToken == "" {
-			break
+			return allPods, pods.ResourceVersion, nil
+		}
+	}
+}
+
+// podFieldSelector returns the field selector pushing pod filters down to the API server.
+// The --field-selector given by the user and the node name are combined with AND semantics.
+func podFieldSelector(options ActionOptions) string {
+	selectors := make([]string, 0, 2)
+	if options.FieldSelector != "" {
+		selectors = append(selectors, options.FieldSelector)
+	}
+	if options.NodeName != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("spec.nodeName", options.NodeName).String())
+	}
+	return strings.Join(selectors, ",")
+}
+
+func podsToUnstructured(pods []*v1.Pod) ([]unstructured.Unstructured, error) {
+	unstructuredPods := make([]unstructured.Unstructured, len(pods))
+	for i, pod := range pods {
+		unstr, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert pod %s to unstructured: %w", pod.Name, err)
+		}
+		unstructuredPods[i] = unstructured.Unstructured{Object: unstr}
+	}
+	return unstructuredPods, nil
+}
+
+// printPodPatchDiff prints the changes the patch would make to the pod.
+func printPodPatchDiff(out io.Writer, pod *v1.Pod, patch []byte, options ActionOptions) error {
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return fmt.Errorf("failed to encode pod %s: %w", pod.Name, err)
+	}
+	return printPatchDiff(out, "pod/"+pod.Name, original, patch, options.PatchStrategy, &v1.Pod{})
+}
+
+// podPatchChanged returns true if the patch changed the pod, see patchChanged.
+func podPatchChanged(patchType k8s_types.PatchType, patch []byte, before, after *v1.Pod) (bool, error) {
+	beforeContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert pod %s to unstructured: %w", before.Name, err)
+	}
+	afterContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert pod %s to unstructured: %w", after.Name, err)
+	}
+	changed, err := patchChanged(patchType, patch, beforeContent, afterContent, &v1.Pod{})
+	if err != nil {
+		return false, fmt.Errorf("failed to check changes of pod %s: %w", before.Name, err)
+	}
+	return changed, nil
+}
+
+// renderPodPatch renders the patch for the pod, converting it to unstructured content only for patch templates.
+func renderPodPatch(options ActionOptions, pod *v1.Pod) ([]byte, error) {
+	if options.PatchTemplate == nil {
+		return renderPatch(options, nil)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pod %s to unstructured: %w", pod.Name, err)
+	}
+	return renderPatch(options, content)
+}
+
+// printPods prints pods with the handler printer.
+func (p *PodHandler) printPods(ctx context.Context, pods []*v1.Pod, out io.Writer) error {
+	if p.nameOutput {
+		for _, pod := range pods {
+			if err := printers.PrintName(out, "pod", pod.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	unstructuredPods, err := podsToUnstructured(pods)
+	if err != nil {
+		return err
+	}
+	return p.printer.PrintObjects(ctx, unstructuredPods, out)
+}
+
+// readyPods returns pods that are running, ready and not terminating, and the number of other pods.
+func readyPods(pods []*v1.Pod) ([]*v1.Pod, int) {
+	ready := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if isPodReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+	return ready, len(pods) - len(ready)
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// hasContainerNamed returns true if any regular or init container name of the pod matches the regex.
+func hasContainerNamed(pod *v1.Pod, regex *regexp.Regexp) bool {
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			if regex.MatchString(container.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseImageRegistry validates the registry host and normalizes it the way image references are normalized,
+// e.g. index.docker.io becomes docker.io.
+func ParseImageRegistry(host string) (string, error) {
+	if strings.Contains(host, "/") {
+		return "", fmt.Errorf("invalid registry host %q, it must not contain a path", host)
+	}
+	named, err := reference.ParseNormalizedNamed(host + "/image")
+	if err != nil {
+		return "", fmt.Errorf("invalid registry host %q: %w", host, err)
+	}
+	registry := reference.Domain(named)
+	// hosts without a dot or a port are parsed as a docker.io repository path
+	if registry == dockerHubRegistry && host != dockerHubRegistry && host != "index.docker.io" {
+		return "", fmt.Errorf("invalid registry host %q, it must contain a dot or a port, e.g. registry.example.com", host)
+	}
+	return registry, nil
+}
+
+// hasImageFromRegistry returns true if any regular or init container image is pulled from the registry.
+// Images without a registry host, like nginx or library/nginx, are pulled from docker.io.
+func hasImageFromRegistry(pod *v1.Pod, registry string) bool {
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			named, err := reference.ParseNormalizedNamed(container.Image)
+			if err == nil && reference.Domain(named) == registry {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasImageTag returns true if any regular or init container image has the tag.
+// Images without a tag and digest are pulled with the latest tag, images pinned only by a digest have no tag.
+func hasImageTag(pod *v1.Pod, tag string) bool {
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			named, err := reference.ParseNormalizedNamed(container.Image)
+			if err != nil {
+				continue
+			}
+			if tagged, ok := reference.TagNameOnly(named).(reference.Tagged); ok && tagged.Tag() == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// imagesPinnedByDigest returns true if every regular and init container image is referenced by a digest,
+// with or without a tag. Unparsable images are treated as not pinned.
+func imagesPinnedByDigest(pod *v1.Pod) bool {
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			named, err := reference.ParseNormalizedNamed(container.Image)
+			if err != nil {
+				return false
+			}
+			if _, ok := named.(reference.Digested); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// podRestarts returns the total restart count of the pod containers, as in the RESTARTS column.
+func podRestarts(pod *v1.Pod) int32 {
+	var total int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		total += cs.RestartCount
+	}
+	return total
+}
+
+// isReschedulable returns true if draining the node would move the pod elsewhere:
+// DaemonSet pods are recreated on the same node and mirror pods are managed by the kubelet, so they are not.
+func isReschedulable(pod *v1.Pod) bool {
+	if _, mirror := pod.Annotations[v1.MirrorPodAnnotationKey]; mirror {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// hasEnvVar returns true if any regular or init container of the pod declares the environment variable.
+// Values from valueFrom sources (config maps, secrets, fields) are not resolved, so such variables only match by name.
+func hasEnvVar(pod *v1.Pod, filter EnvVarFilter) bool {
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.Name != filter.Name {
+					continue
+				}
+				if !filter.HasValue || (env.ValueFrom == nil && env.Value == filter.Value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// requiresNodeLabel returns true if the pod node selector or any term of its required node affinity
+// demands the node label with the value. Preferred (soft) affinity terms are ignored.
+func requiresNodeLabel(pod *v1.Pod, label NodeLabel) bool {
+	if value, ok := pod.Spec.NodeSelector[label.Key]; ok && value == label.Value {
+		return true
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == label.Key && expression.Operator == v1.NodeSelectorOpIn &&
+				slices.Contains(expression.Values, label.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usesSecret returns true if the pod references the secret in a volume, a projected volume, an image pull secret,
+// or in envFrom or env valueFrom of any regular or init container.
+func usesSecret(pod *v1.Pod, name string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == name {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	for _, pullSecret := range pod.Spec.ImagePullSecrets {
+		if pullSecret.Name == name {
+			return true
+		}
+	}
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+					return true
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// usesConfigMap returns true if the pod references the config map in a volume, a projected volume,
+// or in envFrom or env valueFrom of any regular or init container.
+func usesConfigMap(pod *v1.Pod, name string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == name {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil && source.ConfigMap.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == name {
+					return true
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil && env.ValueFrom.ConfigMapKeyRef.Name == name {
+					return true
+				}
+			}
 		}
 	}
-	return allPods, nil
+	return false
+}
+
+// printExecPreview prints the pods the command would be executed on and/or their number, without executing it.
+func printExecPreview(pods []*v1.Pod, options ActionOptions) error {
+	if options.DryRun {
+		for _, pod := range pods {
+			_, err := fmt.Fprintf(options.Streams.Out, "Would execute %q on pod %s in namespace %s\n",
+				options.Exec, pod.Name, pod.Namespace)
+			if err != nil {
+				return fmt.Errorf("failed to write to output: %w", err)
+			}
+		}
+	}
+	if options.Count {
+		if _, err := fmt.Fprintf(options.Streams.Out, "%d\n", len(pods)); err != nil {
+			return fmt.Errorf("failed to write to output: %w", err)
+		}
+	}
+	return nil
 }
 
 // HandleAction implements ResourceHandler.
 func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) error {
+	if options.PatchStrategy == "" {
+		options.PatchStrategy = k8s_types.StrategicMergePatchType
+	}
 	matcher := p.getMatcher(options)
 
-	pods, err := p.getAllPods(ctx, options)
+	stopListing := options.Profiler.Start(ProgressPhaseListing)
+	pods, resourceVersion, err := p.getAllPods(ctx, options)
+	if searchesForbiddenNamespaces(options, true, err) {
+		pods, err = listAccessibleNamespaces(ctx, options, p.namespaceNames, p.getAllPods, err)
+		pods = uniqueByUID(pods, podUID)
+	}
+	stopListing(len(pods))
 	if err != nil {
 		return fmt.Errorf("failed to list pods: %w", err)
 	}
+	if needsLabelHint(options, len(pods)) {
+		p.hintLabelKeys(ctx, options)
+	}
 
 	matchedPods := make([]*v1.Pod, 0, len(pods))
 	for _, pod := range pods {
-		if matcher(&pod) {
+		matched := matcher(&pod)
+		logMatch("pod", pod.Namespace, pod.Name, matched)
+		if matched {
 			matchedPods = append(matchedPods, &pod)
 		}
 	}
+	options.Stats.Add(len(pods), len(matchedPods))
+	for _, pod := range matchedPods {
+		options.auditMatched(pod)
+		options.ByNamespace.Add(pod)
+	}
 
-	if len(matchedPods) == 0 {
+	sortMatched(options, sortby.PodSlice(matchedPods), map[string]sort.Interface{
+		SortByAge:      sortby.PodsByAge(matchedPods),
+		SortByRestarts: sortby.PodsByRestarts{Pods: matchedPods, Restarts: podRestarts},
+		SortByStatus:   sortby.PodsByStatus(matchedPods),
+	})
+
+	if options.Watch {
+		return p.watch(ctx, options, matcher, matchedPods, resourceVersion)
+	}
+	if options.RestartTimeline {
+		if len(matchedPods) != 1 {
+			return fmt.Errorf("--restart-timeline requires exactly one matching pod, but found %d", len(matchedPods))
+		}
+		return printRestartTimeline(matchedPods[0], options.Streams.Out)
+	}
+	if len(matchedPods) == 0 && !options.Count {
 		return nil
 	}
 
-	if options.NaturalSort {
-		sort.Sort(sortby.PodSlice(matchedPods))
+	if options.SaveTo != "" {
+		var unstructuredPods []unstructured.Unstructured
+		if unstructuredPods, err = podsToUnstructured(matchedPods); err != nil {
+			return err
+		}
+		if err = saveObjects(options.SaveTo, unstructuredPods, v1.SchemeGroupVersion.WithKind("Pod")); err != nil {
+			return fmt.Errorf("failed to save pods: %w", err)
+		}
+		fmt.Fprintf(options.Streams.ErrOut, "Saved %d pods to %s\n", len(matchedPods), options.SaveTo)
 	}
 
 	switch options.Action {
 	case ActionList:
-		unstructuredPods := make([]unstructured.Unstructured, len(matchedPods))
-		for i, pod := range matchedPods {
-			var unstr map[string]interface{}
-			unstr, err = runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
-			if err != nil {
-				return fmt.Errorf("failed to convert pod %s to unstructured: %w", pod.Name, err)
-			}
-			unstructuredPods[i] = unstructured.Unstructured{Object: unstr}
+		if options.ListImages {
+			return printImageSummary(matchedPods, options.Streams.Out)
 		}
-
-		return p.printer.PrintObjects(unstructuredPods, options.Streams.Out)
+		if options.Colocated {
+			return printColocatedPods(matchedPods, options.Streams.Out)
+		}
+		return p.printPods(ctx, matchedPods, options.Streams.Out)
 	case ActionDelete:
+		verb, done := "delete", "Deleted"
+		if options.Evict {
+			verb, done = "evict", "Evicted"
+		}
 		if !options.SkipConfirm {
-			_, err = options.Streams.ErrOut.Write([]byte("The following pods will be deleted:\n"))
+			_, err = fmt.Fprintf(options.Streams.ErrOut, "The following pods will be %s:\n", strings.ToLower(done))
 			if err != nil {
 				return fmt.Errorf("failed to write to error output: %w", err)
 			}
@@ -137,40 +531,73 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				return nil
 			}
 		}
-		for _, pod := range matchedPods {
+		defer options.Profiler.Start(ProgressPhaseDeleting)(len(matchedPods))
+		for i, pod := range matchedPods {
 			deletionPropagation := metav1.DeletePropagationBackground
 			deleteOptions := metav1.DeleteOptions{PropagationPolicy: &deletionPropagation}
 			if options.Force {
 				gracePeriod := int64(0)
 				deleteOptions.GracePeriodSeconds = &gracePeriod
 			}
-			err = p.clientSet.CoreV1().
-				Pods(pod.ObjectMeta.Namespace).
-				Delete(ctx, pod.Name, deleteOptions)
+			if options.Evict {
+				err = p.evictPod(ctx, pod, deleteOptions, options.EvictTimeout)
+			} else {
+				err = p.clientSet.CoreV1().
+					Pods(pod.ObjectMeta.Namespace).
+					Delete(ctx, pod.Name, deleteOptions)
+			}
+			options.auditDone(pod, err)
 			if err != nil {
-				return fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
+				return fmt.Errorf("failed to %s pod %s: %w", verb, pod.Name, err)
 			}
-			_, err = fmt.Fprintf(options.Streams.Out, "Deleted pod %s in namespace %s\n", pod.Name, pod.Namespace)
+			_, err = fmt.Fprintf(options.Streams.Out, "%s pod %s in namespace %s\n", done, pod.Name, pod.Namespace)
 			if err != nil {
 				return fmt.Errorf("failed to write to output: %w", err)
 			}
+			options.reportProgress(ProgressEvent{Phase: ProgressPhaseDeleting, Done: i + 1, Total: len(matchedPods)})
 		}
 
 		return nil
 	case ActionPatch:
-		if options.Patch == "" {
+		if options.Patch == "" && options.PatchTemplate == nil {
 			return errors.New("patch content is required for patch action")
 		}
-		if !options.SkipConfirm {
-			_, err = options.Streams.ErrOut.Write([]byte("The following pods will be patched:\n"))
-			if err != nil {
-				return fmt.Errorf("failed to write to error output: %w", err)
+		patches := make([][]byte, len(matchedPods))
+		for i, pod := range matchedPods {
+			if patches[i], err = renderPodPatch(options, pod); err != nil {
+				return fmt.Errorf("pod %s: %w", pod.Name, err)
 			}
-			for _, pod := range matchedPods {
-				_, err = fmt.Fprintf(options.Streams.ErrOut, "- %s in namespace %s\n", pod.Name, pod.Namespace)
+			if err = checkJSONPatch(options, patches[i], pod); err != nil {
+				return fmt.Errorf("pod %s: %w", pod.Name, err)
+			}
+		}
+		if options.Diff {
+			for i, pod := range matchedPods {
+				if err = printPodPatchDiff(options.Streams.Out, pod, patches[i], options); err != nil {
+					return err
+				}
+			}
+		}
+		if !options.SkipConfirm {
+			if !options.Diff {
+				_, err = options.Streams.ErrOut.Write([]byte("The following pods will be patched:\n"))
 				if err != nil {
 					return fmt.Errorf("failed to write to error output: %w", err)
 				}
+				for _, pod := range matchedPods {
+					_, err = fmt.Fprintf(options.Streams.ErrOut, "- %s in namespace %s\n", pod.Name, pod.Namespace)
+					if err != nil {
+						return fmt.Errorf("failed to write to error output: %w", err)
+					}
+				}
+			}
+			if options.PreviewDiff {
+				err = printPreviewDiffs(options.Streams.ErrOut, len(matchedPods), func(i int, out io.Writer) error {
+					return printPodPatchDiff(out, matchedPods[i], patches[i], options)
+				})
+				if err != nil {
+					return err
+				}
 			}
 			if !prompts.AskForConfirmation(options.Streams) {
 				_, err = options.Streams.ErrOut.Write([]byte("Patch cancelled.\n"))
@@ -180,17 +607,44 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				return nil
 			}
 		}
-		for _, pod := range matchedPods {
-			_, err = p.clientSet.CoreV1().
+		conflicted, changed := 0, 0
+		defer options.Profiler.Start(ProgressPhasePatching)(len(matchedPods))
+		for i, pod := range matchedPods {
+			patchBytes, bodyErr := patchBody(options, patches[i], pod, v1.SchemeGroupVersion.WithKind("Pod"))
+			if bodyErr != nil {
+				return bodyErr
+			}
+			patched, err := p.clientSet.CoreV1().
 				Pods(pod.ObjectMeta.Namespace).
-				Patch(ctx, pod.Name, k8s_types.StrategicMergePatchType, []byte(options.Patch), metav1.PatchOptions{})
+				Patch(ctx, pod.Name, options.PatchStrategy, patchBytes, patchOptions(options))
+			options.auditDone(pod, err)
+			if conflicts, isConflict := applyConflicts(options, err); isConflict {
+				conflicted++
+				printApplyConflicts(options.Streams.ErrOut, "pod "+pod.Name+" in namespace "+pod.Namespace, conflicts)
+				continue
+			}
 			if err != nil {
 				return fmt.Errorf("failed to patch pod %s: %w", pod.Name, err)
 			}
-			_, err = fmt.Fprintf(options.Streams.Out, "Patched pod %s in namespace %s\n", pod.Name, pod.Namespace)
+			podChanged, err := podPatchChanged(options.PatchStrategy, patchBytes, pod, patched)
+			if err != nil {
+				return err
+			}
+			if podChanged {
+				changed++
+			}
+			_, err = fmt.Fprintf(options.Streams.Out, "Patched pod %s in namespace %s%s\n",
+				pod.Name, pod.Namespace, patchedSuffix(podChanged))
 			if err != nil {
 				return fmt.Errorf("failed to write to output: %w", err)
 			}
+			options.reportProgress(ProgressEvent{Phase: ProgressPhasePatching, Done: i + 1, Total: len(matchedPods)})
+		}
+		if err = printPatchSummary(options.Streams.Out, changed, len(matchedPods)-conflicted, "pods"); err != nil {
+			return fmt.Errorf("failed to write to output: %w", err)
+		}
+		if conflicted > 0 {
+			return applyConflictsError(conflicted, len(matchedPods), "pods")
 		}
 	case ActionAnnotate:
 		if options.Annotate.IsEmpty() {
@@ -219,10 +673,12 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				return nil
 			}
 		}
-		for _, pod := range matchedPods {
+		defer options.Profiler.Start(ProgressPhaseAnnotating)(len(matchedPods))
+		for i, pod := range matchedPods {
 			_, err = p.clientSet.CoreV1().
 				Pods(pod.ObjectMeta.Namespace).
 				Patch(ctx, pod.Name, k8s_types.MergePatchType, patchBytes, metav1.PatchOptions{})
+			options.auditDone(pod, err)
 			if err != nil {
 				return fmt.Errorf("failed to annotate pod %s: %w", pod.Name, err)
 			}
@@ -230,11 +686,25 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 			if err != nil {
 				return fmt.Errorf("failed to write to output: %w", err)
 			}
+			options.reportProgress(ProgressEvent{Phase: ProgressPhaseAnnotating, Done: i + 1, Total: len(matchedPods)})
 		}
 	case ActionExec:
 		if options.Exec == "" {
 			return errors.New("exec command is required for exec action")
 		}
+		if !options.IncludeNotReady {
+			var notReady int
+			if matchedPods, notReady = readyPods(matchedPods); notReady > 0 {
+				fmt.Fprintf(options.Streams.ErrOut,
+					"Skipping %d not ready pods, use --include-not-ready to execute the command on them\n", notReady)
+			}
+			if len(matchedPods) == 0 && !options.Count {
+				return nil
+			}
+		}
+		if options.DryRun || options.Count {
+			return printExecPreview(matchedPods, options)
+		}
 		if !options.SkipConfirm {
 			_, err = options.Streams.ErrOut.Write([]byte("The following pods will have the command executed:\n"))
 			if err != nil {
@@ -254,7 +724,8 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				return nil
 			}
 		}
-		for _, pod := range matchedPods {
+		defer options.Profiler.Start(ProgressPhaseExecuting)(len(matchedPods))
+		for i, pod := range matchedPods {
 			rest := p.clientSet.CoreV1().RESTClient().
 				Post().
 				Resource("pods").
@@ -275,6 +746,7 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				rest.URL(),
 			)
 			if err != nil {
+				options.auditDone(pod, err)
 				return fmt.Errorf("failed to create executor for pod %s: %w", pod.Name, err)
 			}
 
@@ -284,10 +756,18 @@ func (p *PodHandler) HandleAction(ctx context.Context, options ActionOptions) er
 				Stderr: options.Streams.ErrOut,
 				Tty:    false,
 			})
+			options.auditDone(pod, err)
 			if err != nil {
 				return fmt.Errorf("failed to execute command on pod %s: %w", pod.Name, err)
 			}
+			options.reportProgress(ProgressEvent{Phase: ProgressPhaseExecuting, Done: i + 1, Total: len(matchedPods)})
+		}
+	case ActionExecEach:
+		var unstructuredPods []unstructured.Unstructured
+		if unstructuredPods, err = podsToUnstructured(matchedPods); err != nil {
+			return err
 		}
+		return execEach(ctx, options, unstructuredPods, "pods")
 	default:
 		panic("unimplemented action")
 	}
@@ -301,6 +781,9 @@ func (p *PodHandler) getMatcher(opts ActionOptions) func(pod *v1.Pod) bool {
 		if regex != nil && !regex.MatchString(pod.Name) {
 			return false
 		}
+		if nameExcluded(pod.Name, opts.NameExclude) {
+			return false
+		}
 		if opts.MinAge != 0 {
 			if time.Since(pod.CreationTimestamp.Time) < opts.MinAge {
 				return false
@@ -316,6 +799,23 @@ func (p *PodHandler) getMatcher(opts ActionOptions) func(pod *v1.Pod) bool {
 				return false
 			}
 		}
+		if opts.Stale {
+			if pod.Status.ObservedGeneration == 0 || pod.Generation == pod.Status.ObservedGeneration {
+				return false
+			}
+		}
+		if !createdBetween(pod.CreationTimestamp.Time, opts) {
+			return false
+		}
+		if opts.NodeName != "" && pod.Spec.NodeName != opts.NodeName {
+			return false
+		}
+		if opts.Reschedulable && !isReschedulable(pod) {
+			return false
+		}
+		if (opts.Standalone || opts.Controlled) && (metav1.GetControllerOf(pod) != nil) != opts.Controlled {
+			return false
+		}
 		if opts.NodeNameRegex != nil {
 			nodeName := pod.Spec.NodeName
 			if nodeName == "" {
@@ -328,6 +828,68 @@ func (p *PodHandler) getMatcher(opts ActionOptions) func(pod *v1.Pod) bool {
 				return false
 			}
 		}
+		if opts.ContainerRegex != nil && !hasContainerNamed(pod, opts.ContainerRegex) {
+			return false
+		}
+		if opts.ImageRegistry != "" && !hasImageFromRegistry(pod, opts.ImageRegistry) {
+			return false
+		}
+		if opts.ImageTag != "" && !hasImageTag(pod, opts.ImageTag) {
+			return false
+		}
+		if (opts.ByDigest || opts.NoDigest) && imagesPinnedByDigest(pod) != opts.ByDigest {
+			return false
+		}
+		if (opts.VolumeType != "" || opts.VolumeName != "") && !hasVolume(pod, opts.VolumeType, opts.VolumeName) {
+			return false
+		}
+		if opts.RequiredLabel.Key != "" && !requiresNodeLabel(pod, opts.RequiredLabel) {
+			return false
+		}
+		if opts.UsesSecret != "" && !usesSecret(pod, opts.UsesSecret) {
+			return false
+		}
+		if opts.PodIP != nil && !hasPodIP(pod, opts.PodIP) {
+			return false
+		}
+		if opts.PodIPRange != nil && !hasPodIPInRange(pod, opts.PodIPRange) {
+			return false
+		}
+		if opts.HostIP != nil && !hasHostIP(pod, opts.HostIP) {
+			return false
+		}
+		if opts.UsesPVC != "" && !usesPVC(pod, opts.UsesPVC) {
+			return false
+		}
+		if opts.UsesConfigMap != "" && !usesConfigMap(pod, opts.UsesConfigMap) {
+			return false
+		}
+		for _, env := range opts.EnvVars {
+			if !hasEnvVar(pod, env) {
+				return false
+			}
+		}
+		if len(opts.LabelCompare) > 0 && !labelComparisonsMatch(pod.Labels, opts.LabelCompare) {
+			return false
+		}
+		if len(opts.Conditions) > 0 && !conditionsMatch(podConditions(pod), opts.Conditions) {
+			return false
+		}
+		if len(opts.ConditionAges) > 0 && !conditionAgesMatch(podConditions(pod), opts.ConditionAges) {
+			return false
+		}
+		if opts.MinRestarts > 0 && podRestarts(pod) < opts.MinRestarts {
+			return false
+		}
+		if opts.RestartReason != "" && !hasTerminationReason(pod, opts.RestartReason) {
+			return false
+		}
+		if opts.ExitCode != nil && !hasTerminationExitCode(pod, opts.ExitCode) {
+			return false
+		}
+		if opts.ExitCodeNonzero && !hasTerminationExitCode(pod, nil) {
+			return false
+		}
 		if opts.Restarted {
 			for _, cs := range pod.Status.ContainerStatuses {
 				if cs.RestartCount > 0 {
@@ -361,6 +923,30 @@ func (p *PodHandler) getMatcher(opts ActionOptions) func(pod *v1.Pod) bool {
 	}
 }
 
+// evictPod evicts the pod, so pod disruption budgets are respected like by kubectl drain.
+// While a disruption budget blocks the eviction, it is retried until the timeout.
+func (p *PodHandler) evictPod(ctx context.Context, pod *v1.Pod, deleteOptions metav1.DeleteOptions, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := p.clientSet.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			DeleteOptions: &deleteOptions,
+		})
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+		if time.Now().Add(evictionRetryInterval).After(deadline) {
+			return fmt.Errorf("eviction is still blocked after %s: %w", timeout, err)
+		}
+		klog.V(3).InfoS("Eviction blocked, retrying", "pod", klog.KObj(pod), "err", err.Error())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(evictionRetryInterval):
+		}
+	}
+}
+
 // IsExecutable implements ResourceHandler.
 func (p *PodHandler) IsExecutable() bool {
 	return true
@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,11 +21,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 func TestPodsHandler(t *testing.T) {
@@ -57,7 +65,7 @@ func TestPodsHandler(t *testing.T) {
 			name: "List Pods",
 			prepare: func(t *testing.T, f *fields, _ *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.Cond(func(obj []unstructured.Unstructured) bool {
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Cond(func(obj []unstructured.Unstructured) bool {
 					if len(obj) != 1 {
 						return false
 					}
@@ -97,7 +105,7 @@ func TestPodsHandler(t *testing.T) {
 			},
 			prepare: func(t *testing.T, f *fields, _ *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 
 				f.printer = m
 				return nil
@@ -107,7 +115,7 @@ func TestPodsHandler(t *testing.T) {
 			name: "List in empty namespace",
 			prepare: func(t *testing.T, f *fields, _ *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 
 				f.printer = m
 				return nil
@@ -133,7 +141,10 @@ func TestPodsHandler(t *testing.T) {
 			name: "List in all namespaces",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -171,7 +182,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -217,10 +228,13 @@ func TestPodsHandler(t *testing.T) {
 			},
 		},
 		{
-			name: "List pods with double regex",
+			name: "List pods with regex matching in the middle of the name",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -254,11 +268,56 @@ func TestPodsHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "List pods with name exclude regex",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:   "default",
+					Action:      ActionList,
+					NameRegex:   regexp.MustCompile(".*admin.*"),
+					NameExclude: []*regexp.Regexp{regexp.MustCompile("-2$")},
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "admin-pod",
+							Namespace: "default",
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "hello-admin-pod-2",
+							Namespace: "default",
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "other-pod",
+							Namespace: "default",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "List pods with min age",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -298,7 +357,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[2:]...)), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[2:]...)), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -359,7 +418,10 @@ func TestPodsHandler(t *testing.T) {
 			name: "List pods with specific status",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -582,7 +644,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -639,7 +701,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -700,7 +762,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -770,7 +832,7 @@ func TestPodsHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				m.EXPECT().
-					PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
 					Return(nil).
 					Times(1)
 				f.printer = m
@@ -819,50 +881,1364 @@ func TestPodsHandler(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	test := func(prepare func(*testing.T, *fields, *shared) error, args args, shared shared, want want) func(t *testing.T) {
-		return func(t *testing.T) {
-			t.Helper()
-
-			for _, resource := range shared.resources {
-				if pod, ok := resource.(*v1.Pod); ok {
-					// Set a default creation timestamp if not set
-					if pod.CreationTimestamp.IsZero() {
-						pod.CreationTimestamp = metav1.NewTime(time.Now())
-					}
-				}
-			}
-
-			ff := fields{}
-			ff.clientSet = fake.NewClientset(shared.resources...)
-
-			shared.streams, shared.in, shared.out, shared.errOut = genericclioptions.NewTestIOStreams()
-
-			require.NoError(t, prepare(t, &ff, &shared))
-
-			handler := PodHandler{
-				clientSet:      ff.clientSet,
-				printer:        ff.printer,
-				executorGetter: ff.executorGetter,
-			}
-
-			args.options.Streams = &shared.streams
-			err := handler.HandleAction(t.Context(), args.options)
-			if want.err != nil {
-				require.Error(t, err)
-				require.EqualError(t, err, want.err.Error())
-			} else {
-				require.NoError(t, err)
-			}
-
-			if want.check != nil {
-				want.check(t, &ff, &shared)
-			}
-		}
-	}
+		{
+			name: "List stale pods",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace: "default",
+					Action:    ActionList,
+					Stale:     true,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "resized-pod",
+							Namespace:  "default",
+							Generation: 2,
+						},
+						Status: v1.PodStatus{ObservedGeneration: 1},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "observed-pod",
+							Namespace:  "default",
+							Generation: 2,
+						},
+						Status: v1.PodStatus{ObservedGeneration: 2},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "untracked-pod",
+							Namespace:  "default",
+							Generation: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List images of pods",
+			prepare: func(t *testing.T, f *fields, _ *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 
-	for _, tt := range tests {
-		t.Run(tt.name, test(tt.prepare, tt.args, tt.shared, tt.want))
-	}
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:  "default",
+					Action:     ActionList,
+					ListImages: true,
+				},
+			},
+			want: want{
+				check: func(t *testing.T, _ *fields, s *shared) {
+					assert.Equal(t, [][]string{
+						{"IMAGE", "PODS"},
+						{"nginx:1.27", "3"},
+						{"envoy:1.30", "2"},
+						{"busybox:1.36", "1"},
+					}, tableFields(s.out.String()))
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+						Spec: v1.PodSpec{
+							InitContainers: []v1.Container{{Name: "init", Image: "busybox:1.36"}},
+							Containers: []v1.Container{
+								{Name: "app", Image: "nginx:1.27"},
+								{Name: "proxy", Image: "envoy:1.30"},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Image: "nginx:1.27"},
+								{Name: "proxy", Image: "envoy:1.30"},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Image: "nginx:1.27"},
+								{Name: "sidecar", Image: "nginx:1.27"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods matching container name regex",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:      "default",
+					Action:         ActionList,
+					ContainerRegex: regexp.MustCompile("^istio-"),
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Image: "nginx:1.27"},
+								{Name: "istio-proxy", Image: "istio/proxyv2:1.26"},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+						Spec: v1.PodSpec{
+							InitContainers: []v1.Container{{Name: "istio-init", Image: "istio/proxyv2:1.26"}},
+							Containers:     []v1.Container{{Name: "app", Image: "api:2.0"}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app-istio-proxy", Image: "worker:1.0"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods matching container name and image regex",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:      "default",
+					Action:         ActionList,
+					ContainerRegex: regexp.MustCompile("^istio-proxy$"),
+					ImageRegex:     regexp.MustCompile(":1\\.26$"),
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "istio-proxy", Image: "istio/proxyv2:1.26"}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "istio-proxy", Image: "istio/proxyv2:1.25"}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app", Image: "worker:1.26"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods declaring environment variable with value",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace: "default",
+					Action:    ActionList,
+					EnvVars:   []EnvVarFilter{{Name: "DB_HOST", Value: "db-old.internal", HasValue: true}},
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Env: []v1.EnvVar{
+									{Name: "LOG_LEVEL", Value: "info"},
+									{Name: "DB_HOST", Value: "db-old.internal"},
+								}},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+						Spec: v1.PodSpec{
+							InitContainers: []v1.Container{
+								{Name: "migrations", Env: []v1.EnvVar{{Name: "DB_HOST", Value: "db-old.internal"}}},
+							},
+							Containers: []v1.Container{{Name: "app"}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Env: []v1.EnvVar{{Name: "DB_HOST", Value: "db-new.internal"}}},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Env: []v1.EnvVar{{Name: "DB_HOST", ValueFrom: &v1.EnvVarSource{
+									ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+										LocalObjectReference: v1.LocalObjectReference{Name: "db"},
+										Key:                  "host",
+									},
+								}}}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods declaring environment variables by name",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1:3]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace: "default",
+					Action:    ActionList,
+					EnvVars:   []EnvVarFilter{{Name: "DB_HOST"}, {Name: "DEBUG", Value: "", HasValue: true}},
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app", Env: []v1.EnvVar{{Name: "DB_HOST", Value: "db"}}}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Env: []v1.EnvVar{{Name: "DB_HOST", Value: "db"}, {Name: "DEBUG"}}},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								{Name: "app", Env: []v1.EnvVar{{Name: "DEBUG"}}},
+								{Name: "sidecar", Env: []v1.EnvVar{{Name: "DB_HOST", Value: "db"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods mounting a host path",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:  "default",
+					Action:     ActionList,
+					VolumeType: VolumeTypeHostPath,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "node-exporter", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Volumes: []v1.Volume{{Name: "proc", VolumeSource: v1.VolumeSource{
+								HostPath: &v1.HostPathVolumeSource{Path: "/proc"},
+							}}},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec: v1.PodSpec{
+							Volumes: []v1.Volume{{Name: "proc", VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{},
+							}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List pods restarted at least min restarts times",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1], s.resources[2])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:   "default",
+					Action:      ActionList,
+					MinRestarts: 5,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "below-threshold",
+							Namespace: "default",
+						},
+						Status: v1.PodStatus{
+							Phase: v1.PodRunning,
+							ContainerStatuses: []v1.ContainerStatus{
+								{Name: "app-0", RestartCount: 4},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "at-threshold",
+							Namespace: "default",
+						},
+						Status: v1.PodStatus{
+							Phase: v1.PodRunning,
+							ContainerStatuses: []v1.ContainerStatus{
+								{Name: "app-0", RestartCount: 5},
+							},
+						},
+					},
+					&v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "sidecar-restarts",
+							Namespace: "default",
+						},
+						Status: v1.PodStatus{
+							Phase: v1.PodRunning,
+							ContainerStatuses: []v1.ContainerStatus{
+								{Name: "app-0", RestartCount: 3},
+								{Name: "app-1", RestartCount: 2},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	test := func(prepare func(*testing.T, *fields, *shared) error, args args, shared shared, want want) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Helper()
+
+			for _, resource := range shared.resources {
+				if pod, ok := resource.(*v1.Pod); ok {
+					// Set a default creation timestamp if not set
+					if pod.CreationTimestamp.IsZero() {
+						pod.CreationTimestamp = metav1.NewTime(time.Now())
+					}
+				}
+			}
+
+			ff := fields{}
+			ff.clientSet = fake.NewClientset(shared.resources...)
+
+			shared.streams, shared.in, shared.out, shared.errOut = genericclioptions.NewTestIOStreams()
+
+			require.NoError(t, prepare(t, &ff, &shared))
+
+			handler := PodHandler{
+				clientSet:      ff.clientSet,
+				printer:        ff.printer,
+				executorGetter: ff.executorGetter,
+			}
+
+			args.options.Streams = &shared.streams
+			err := handler.HandleAction(t.Context(), args.options)
+			if want.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, err, want.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			if want.check != nil {
+				want.check(t, &ff, &shared)
+			}
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, test(tt.prepare, tt.args, tt.shared, tt.want))
+	}
+}
+
+// tableFields splits printed table output into rows of whitespace separated fields.
+func tableFields(out string) [][]string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	rows := make([][]string, len(lines))
+	for i, line := range lines {
+		rows[i] = strings.Fields(line)
+	}
+	return rows
+}
+
+func TestPodHandler_AlsoJSON(t *testing.T) {
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+	)
+	jsonOut := &bytes.Buffer{}
+	handler, err := GetResourceHandler(podResource, NewHandlerOptions().
+		WithClientSet(clientSet).
+		WithNameOutput(true).
+		WithAlsoJSON(jsonOut))
+	require.NoError(t, err)
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err = handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1\npod/web-2\n", out.String())
+
+	lines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	require.Len(t, lines, 2, "every matched pod is written to the JSON output too")
+	for i, line := range lines {
+		var pod unstructured.Unstructured
+		require.NoError(t, pod.UnmarshalJSON([]byte(line)))
+		assert.Equal(t, "Pod", pod.GetKind())
+		assert.Equal(t, fmt.Sprintf("web-%d", i+1), pod.GetName())
+	}
+}
+
+func TestPodHandler_NameOutput(t *testing.T) {
+	m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+	m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	handler := PodHandler{
+		clientSet: fake.NewClientset(
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		),
+		printer:    m,
+		nameOutput: true,
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionList,
+		NaturalSort: true,
+		Streams:     &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1\npod/web-2\n", out.String())
+}
+
+func TestPodHandler_OnNode(t *testing.T) {
+	onNode := func(name, node string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: node},
+		}
+	}
+	clientSet := fake.NewClientset(
+		onNode("web-1", "ip-10-0-1-2.ec2.internal"),
+		// matches ip-10-0-1-2.ec2.internal as a regular expression
+		onNode("web-2", "ip-10-0-1-2Xec2Xinternal"),
+		onNode("web-3", "ip-10-0-1-22.ec2.internal"),
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-4", Namespace: "default"},
+			Status:     v1.PodStatus{NominatedNodeName: "ip-10-0-1-2.ec2.internal"},
+		},
+	)
+	handler := PodHandler{clientSet: clientSet, nameOutput: true}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		NodeName:  "ip-10-0-1-2.ec2.internal",
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1\n", out.String())
+
+	require.Len(t, clientSet.Actions(), 1)
+	list, ok := clientSet.Actions()[0].(k8stesting.ListAction)
+	require.True(t, ok)
+	assert.Equal(t, "spec.nodeName=ip-10-0-1-2.ec2.internal", list.GetListRestrictions().Fields.String(),
+		"node name must be pushed down to the API server")
+}
+
+func TestPodHandler_FieldSelector(t *testing.T) {
+	clientSet := fake.NewClientset()
+	handler := PodHandler{clientSet: clientSet, nameOutput: true}
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:     "default",
+		Action:        ActionList,
+		LabelSelector: "app=web",
+		FieldSelector: "status.phase=Running",
+		NodeName:      "node-1",
+		Streams:       &streams,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, clientSet.Actions(), 1)
+	list, ok := clientSet.Actions()[0].(k8stesting.ListAction)
+	require.True(t, ok)
+	restrictions := list.GetListRestrictions()
+	assert.Equal(t, "app=web", restrictions.Labels.String())
+	assert.Equal(t, "spec.nodeName=node-1,status.phase=Running", restrictions.Fields.String(),
+		"field selectors must be combined and pushed down to the API server")
+}
+
+func TestIsReschedulable(t *testing.T) {
+	controlledBy := func(kind string) []metav1.OwnerReference {
+		controller := true
+		return []metav1.OwnerReference{{Kind: kind, Name: "owner", Controller: &controller}}
+	}
+
+	tests := []struct {
+		name string
+		meta metav1.ObjectMeta
+		want bool
+	}{
+		{name: "replicaset pod", meta: metav1.ObjectMeta{OwnerReferences: controlledBy("ReplicaSet")}, want: true},
+		{name: "bare pod", want: true},
+		{name: "daemonset pod", meta: metav1.ObjectMeta{OwnerReferences: controlledBy("DaemonSet")}, want: false},
+		{
+			name: "daemonset not controlling the pod",
+			meta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "owner"}}},
+			want: true,
+		},
+		{
+			name: "mirror pod",
+			meta: metav1.ObjectMeta{Annotations: map[string]string{v1.MirrorPodAnnotationKey: "3f2b"}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isReschedulable(&v1.Pod{ObjectMeta: tt.meta}))
+		})
+	}
+}
+
+func TestPodHandler_EvictReschedulable(t *testing.T) {
+	controller := true
+	clientSet := fake.NewClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-1",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web", Controller: &controller}},
+		}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "fluentd-x2k",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd", Controller: &controller}},
+		}},
+	)
+	handler := PodHandler{clientSet: clientSet}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:     "default",
+		Action:        ActionDelete,
+		Reschedulable: true,
+		Evict:         true,
+		SkipConfirm:   true,
+		Streams:       &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Evicted pod web-1 in namespace default\n", out.String())
+
+	var evicted []string
+	for _, action := range clientSet.Actions() {
+		assert.NotEqual(t, "delete", action.GetVerb(), "pods must be evicted, not deleted")
+		if action.GetSubresource() == "eviction" {
+			evicted = append(evicted, action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction).Name)
+		}
+	}
+	assert.Equal(t, []string{"web-1"}, evicted)
+}
+
+func TestPodHandler_EvictRetriesBlockedEviction(t *testing.T) {
+	interval := evictionRetryInterval
+	evictionRetryInterval = time.Millisecond
+	t.Cleanup(func() { evictionRetryInterval = interval })
+
+	tests := []struct {
+		name    string
+		blocked int
+		wantErr string
+		wantOut string
+	}{
+		{
+			name:    "unblocked by the disruption budget",
+			blocked: 2,
+			wantOut: "Evicted pod web-1 in namespace default\n",
+		},
+		{
+			name:    "blocked until the timeout",
+			blocked: 1000,
+			wantErr: "failed to evict pod web-1: eviction is still blocked after 50ms: " +
+				"Cannot evict pod as it would violate the pod's disruption budget.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSet := fake.NewClientset(
+				&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+			)
+			attempts := 0
+			clientSet.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				attempts++
+				if attempts <= tt.blocked {
+					return true, nil, apierrors.NewTooManyRequests(
+						"Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				return false, nil, nil
+			})
+			handler := PodHandler{clientSet: clientSet}
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:    "default",
+				Action:       ActionDelete,
+				Evict:        true,
+				EvictTimeout: 50 * time.Millisecond,
+				SkipConfirm:  true,
+				Streams:      &streams,
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.blocked+1, attempts)
+			}
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestPodHandler_SortByAge(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, age time.Duration) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		reverse bool
+		want    string
+	}{
+		{name: "oldest first", want: "pod/db\npod/api\npod/web\n"},
+		{name: "newest first", reverse: true, want: "pod/web\npod/api\npod/db\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := PodHandler{
+				clientSet:  fake.NewClientset(pod("api", time.Hour), pod("web", time.Minute), pod("db", 24*time.Hour)),
+				nameOutput: true,
+			}
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:   "default",
+				Action:      ActionList,
+				SortBy:      SortByAge,
+				ReverseSort: tt.reverse,
+				Streams:     &streams,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestPodHandler_SortByRestarts(t *testing.T) {
+	pod := func(name string, restarts int32) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+			},
+		}
+	}
+	handler := PodHandler{
+		clientSet:  fake.NewClientset(pod("api", 2), pod("web", 0), pod("worker", 31)),
+		nameOutput: true,
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		SortBy:    SortByRestarts,
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/worker\npod/api\npod/web\n", out.String())
+}
+
+func readyPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func TestPodHandler_ExecPreview(t *testing.T) {
+	tests := []struct {
+		name    string
+		dryRun  bool
+		count   bool
+		pattern string
+		want    string
+	}{
+		{
+			name:   "dry run",
+			dryRun: true,
+			want: "Would execute \"date\" on pod web-1 in namespace default\n" +
+				"Would execute \"date\" on pod web-2 in namespace default\n",
+		},
+		{
+			name:  "count",
+			count: true,
+			want:  "2\n",
+		},
+		{
+			name:   "dry run with count",
+			dryRun: true,
+			count:  true,
+			want: "Would execute \"date\" on pod web-1 in namespace default\n" +
+				"Would execute \"date\" on pod web-2 in namespace default\n2\n",
+		},
+		{
+			name:    "count without matches",
+			count:   true,
+			pattern: "^api-",
+			want:    "0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := PodHandler{
+				clientSet: fake.NewClientset(readyPod("web-2"), readyPod("web-1")),
+				executorGetter: func(string, *url.URL) (remotecommand.Executor, error) {
+					t.Fatal("executor must not be created")
+					return nil, nil
+				},
+			}
+
+			options := ActionOptions{
+				Namespace:   "default",
+				Action:      ActionExec,
+				Exec:        "date",
+				DryRun:      tt.dryRun,
+				Count:       tt.count,
+				NaturalSort: true,
+			}
+			if tt.pattern != "" {
+				options.NameRegex = regexp.MustCompile(tt.pattern)
+			}
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			options.Streams = &streams
+
+			require.NoError(t, handler.HandleAction(t.Context(), options))
+			assert.Equal(t, tt.want, out.String())
+			assert.Empty(t, errOut.String(), "no confirmation prompt is expected")
+		})
+	}
+}
+
+func TestPodHandler_ExecSkipsNotReadyPods(t *testing.T) {
+	notReady := readyPod("web-2")
+	notReady.Status.Conditions[0].Status = v1.ConditionFalse
+	pending := readyPod("web-3")
+	pending.Status = v1.PodStatus{Phase: v1.PodPending}
+	terminating := readyPod("web-4")
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	terminating.Finalizers = []string{"example.com/cleanup"}
+
+	tests := []struct {
+		name            string
+		includeNotReady bool
+		wantOut         string
+		wantErrOut      string
+	}{
+		{
+			name:       "ready pods only",
+			wantOut:    "Would execute \"date\" on pod web-1 in namespace default\n",
+			wantErrOut: "Skipping 3 not ready pods, use --include-not-ready to execute the command on them\n",
+		},
+		{
+			name:            "include not ready",
+			includeNotReady: true,
+			wantOut: "Would execute \"date\" on pod web-1 in namespace default\n" +
+				"Would execute \"date\" on pod web-2 in namespace default\n" +
+				"Would execute \"date\" on pod web-3 in namespace default\n" +
+				"Would execute \"date\" on pod web-4 in namespace default\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := PodHandler{
+				clientSet: fake.NewClientset(readyPod("web-1"), notReady, pending, terminating),
+			}
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:       "default",
+				Action:          ActionExec,
+				Exec:            "date",
+				DryRun:          true,
+				IncludeNotReady: tt.includeNotReady,
+				NaturalSort:     true,
+				Streams:         &streams,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, out.String())
+			assert.Equal(t, tt.wantErrOut, errOut.String())
+		})
+	}
+}
+
+func TestPodHandler_ExecNoReadyPodsNeverPrompts(t *testing.T) {
+	handler := PodHandler{
+		clientSet: fake.NewClientset(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}}),
+		executorGetter: func(string, *url.URL) (remotecommand.Executor, error) {
+			t.Fatal("executor must not be created")
+			return nil, nil
+		},
+	}
+	streams, in, _, errOut := genericclioptions.NewTestIOStreams()
+	in.WriteString("y\n")
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace: "default",
+		Action:    ActionExec,
+		Exec:      "date",
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Skipping 1 not ready pods, use --include-not-ready to execute the command on them\n", errOut.String())
+	assert.Equal(t, "y\n", in.String(), "confirmation must not be read")
+}
+
+func TestPodHandler_NoMatchesNeverPrompts(t *testing.T) {
+	annotate, err := ParseAnnotateFlag("team=platform")
+	require.NoError(t, err)
+
+	for _, action := range []Action{ActionDelete, ActionPatch, ActionAnnotate, ActionExec} {
+		t.Run(action.String(), func(t *testing.T) {
+			clientSet := fake.NewClientset(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}})
+			handler := PodHandler{
+				clientSet: clientSet,
+				executorGetter: func(string, *url.URL) (remotecommand.Executor, error) {
+					t.Fatal("executor must not be created")
+					return nil, nil
+				},
+			}
+
+			// "y" would confirm the action if a prompt was shown
+			streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+			in.WriteString("y\n")
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace: "default",
+				Action:    action,
+				NameRegex: regexp.MustCompile("^missing$"),
+				Patch:     `{"metadata": {"labels": {"team": "platform"}}}`,
+				Annotate:  annotate,
+				Exec:      "date",
+				Streams:   &streams,
+			})
+			require.NoError(t, err)
+
+			assert.Empty(t, out.String())
+			assert.Empty(t, errOut.String(), "neither a preview nor a prompt is expected")
+			assert.Equal(t, "y\n", in.String(), "confirmation must not be read")
+			for _, a := range clientSet.Actions() {
+				assert.Equal(t, "list", a.GetVerb())
+			}
+		})
+	}
+}
+
+func BenchmarkPodHandler_List(b *testing.B) {
+	pods := make([]runtime.Object, 5000)
+	for i := range pods {
+		pods[i] = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("web-%d", i),
+				Namespace:         "default",
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "nginx:1.27"}}},
+		}
+	}
+	clientSet := fake.NewClientset(pods...)
+	streams := genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard}
+	options := ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		NameRegex: regexp.MustCompile("^web-"),
+		Streams:   &streams,
+	}
+
+	for _, tt := range []struct {
+		name       string
+		nameOutput bool
+	}{
+		{name: "unstructured", nameOutput: false},
+		{name: "typed", nameOutput: true},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			handler := PodHandler{
+				clientSet:  clientSet,
+				printer:    printers.NewNamePrinter(printers.NamePrinterOptions{Resource: "pod"}),
+				nameOutput: tt.nameOutput,
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := handler.HandleAction(b.Context(), options); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestUsesSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1.PodSpec
+		want bool
+	}{
+		{
+			name: "volume",
+			spec: v1.PodSpec{Volumes: []v1.Volume{{Name: "tls", VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{SecretName: "db-credentials"},
+			}}}},
+			want: true,
+		},
+		{
+			name: "projected volume",
+			spec: v1.PodSpec{Volumes: []v1.Volume{{Name: "all", VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{{
+					Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "db-credentials"}},
+				}}},
+			}}}},
+			want: true,
+		},
+		{
+			name: "envFrom",
+			spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", EnvFrom: []v1.EnvFromSource{{
+				SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-credentials"}},
+			}}}}},
+			want: true,
+		},
+		{
+			name: "env valueFrom in init container",
+			spec: v1.PodSpec{InitContainers: []v1.Container{{Name: "migrate", Env: []v1.EnvVar{{
+				Name: "DB_PASSWORD",
+				ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "db-credentials"},
+					Key:                  "password",
+				}},
+			}}}}},
+			want: true,
+		},
+		{
+			name: "image pull secret",
+			spec: v1.PodSpec{ImagePullSecrets: []v1.LocalObjectReference{{Name: "db-credentials"}}},
+			want: true,
+		},
+		{
+			name: "other secret and config map of the same name",
+			spec: v1.PodSpec{
+				Volumes: []v1.Volume{{Name: "tls", VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "web-tls"},
+				}}},
+				Containers: []v1.Container{{Name: "app", EnvFrom: []v1.EnvFromSource{{
+					ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-credentials"}},
+				}}}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usesSecret(&v1.Pod{Spec: tt.spec}, "db-credentials"))
+		})
+	}
+}
+
+func TestUsesConfigMap(t *testing.T) {
+	ref := v1.LocalObjectReference{Name: "app-config"}
+	tests := []struct {
+		name string
+		spec v1.PodSpec
+		want bool
+	}{
+		{
+			name: "volume",
+			spec: v1.PodSpec{Volumes: []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: ref},
+			}}}},
+			want: true,
+		},
+		{
+			name: "projected volume",
+			spec: v1.PodSpec{Volumes: []v1.Volume{{Name: "all", VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{{
+					ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: ref},
+				}}},
+			}}}},
+			want: true,
+		},
+		{
+			name: "envFrom",
+			spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", EnvFrom: []v1.EnvFromSource{{
+				ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: ref},
+			}}}}},
+			want: true,
+		},
+		{
+			name: "env valueFrom in init container",
+			spec: v1.PodSpec{InitContainers: []v1.Container{{Name: "migrate", Env: []v1.EnvVar{{
+				Name: "LOG_LEVEL",
+				ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: ref,
+					Key:                  "log-level",
+				}},
+			}}}}},
+			want: true,
+		},
+		{
+			name: "other config map and secret of the same name",
+			spec: v1.PodSpec{
+				Volumes: []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}},
+				}}},
+				Containers: []v1.Container{{Name: "app", EnvFrom: []v1.EnvFromSource{{
+					SecretRef: &v1.SecretEnvSource{LocalObjectReference: ref},
+				}}}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usesConfigMap(&v1.Pod{Spec: tt.spec}, "app-config"))
+		})
+	}
+}
+
+func TestRequiresNodeLabel(t *testing.T) {
+	requiredAffinity := func(expressions ...v1.NodeSelectorRequirement) *v1.Affinity {
+		return &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: expressions}},
+			},
+		}}
+	}
+	gpuLabel := NodeLabel{Key: "accelerator", Value: "nvidia-a100"}
+
+	tests := []struct {
+		name string
+		spec v1.PodSpec
+		want bool
+	}{
+		{
+			name: "node selector",
+			spec: v1.PodSpec{NodeSelector: map[string]string{"accelerator": "nvidia-a100"}},
+			want: true,
+		},
+		{
+			name: "node selector with another value",
+			spec: v1.PodSpec{NodeSelector: map[string]string{"accelerator": "nvidia-t4"}},
+			want: false,
+		},
+		{
+			name: "required node affinity",
+			spec: v1.PodSpec{Affinity: requiredAffinity(v1.NodeSelectorRequirement{
+				Key:      "accelerator",
+				Operator: v1.NodeSelectorOpIn,
+				Values:   []string{"nvidia-t4", "nvidia-a100"},
+			})},
+			want: true,
+		},
+		{
+			name: "required node affinity excluding the value",
+			spec: v1.PodSpec{Affinity: requiredAffinity(v1.NodeSelectorRequirement{
+				Key:      "accelerator",
+				Operator: v1.NodeSelectorOpNotIn,
+				Values:   []string{"nvidia-a100"},
+			})},
+			want: false,
+		},
+		{
+			name: "preferred node affinity",
+			spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{{
+					Weight: 1,
+					Preference: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{
+						Key:      "accelerator",
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{"nvidia-a100"},
+					}}},
+				}},
+			}}},
+			want: false,
+		},
+		{
+			name: "no constraints",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiresNodeLabel(&v1.Pod{Spec: tt.spec}, gpuLabel))
+		})
+	}
+}
+
+func TestParseImageRegistry(t *testing.T) {
+	for host, want := range map[string]string{
+		"docker.io":                 "docker.io",
+		"index.docker.io":           "docker.io",
+		"ghcr.io":                   "ghcr.io",
+		"registry.example.com:5000": "registry.example.com:5000",
+		"localhost:5000":            "localhost:5000",
+	} {
+		registry, err := ParseImageRegistry(host)
+		require.NoError(t, err, host)
+		assert.Equal(t, want, registry, host)
+	}
+
+	_, err := ParseImageRegistry("library")
+	require.EqualError(t, err, `invalid registry host "library", it must contain a dot or a port, e.g. registry.example.com`)
+	_, err = ParseImageRegistry("ghcr.io/org")
+	require.Error(t, err)
+}
+
+func TestHasImageFromRegistry(t *testing.T) {
+	tests := []struct {
+		image    string
+		registry string
+		want     bool
+	}{
+		{image: "nginx", registry: "docker.io", want: true},
+		{image: "nginx:1.27", registry: "docker.io", want: true},
+		{image: "library/nginx", registry: "docker.io", want: true},
+		{image: "docker.io/library/nginx", registry: "docker.io", want: true},
+		{image: "bitnami/redis", registry: "docker.io", want: true},
+		{image: "nginx", registry: "ghcr.io", want: false},
+		{image: "ghcr.io/org/app:v1", registry: "ghcr.io", want: true},
+		{image: "ghcr.io/org/app:v1", registry: "docker.io", want: false},
+		{image: "registry.example.com:5000/team/app@sha256:" + strings.Repeat("a", 64), registry: "registry.example.com:5000", want: true},
+		// a path segment looking like the host is not the registry
+		{image: "mirror.example.com/ghcr.io/org/app", registry: "ghcr.io", want: false},
+		{image: "Invalid:Image", registry: "docker.io", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image+"@"+tt.registry, func(t *testing.T) {
+			pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: tt.image}}}}
+			assert.Equal(t, tt.want, hasImageFromRegistry(pod, tt.registry))
+		})
+	}
+
+	initOnly := &v1.Pod{Spec: v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "migrate", Image: "ghcr.io/org/migrate"}},
+		Containers:     []v1.Container{{Name: "app", Image: "nginx"}},
+	}}
+	assert.True(t, hasImageFromRegistry(initOnly, "ghcr.io"), "init containers are checked too")
+}
+
+func TestHasImageTag(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		image string
+		tag   string
+		want  bool
+	}{
+		{image: "nginx:1.27", tag: "1.27", want: true},
+		{image: "nginx:1.27", tag: "latest", want: false},
+		{image: "nginx:latest", tag: "latest", want: true},
+		{image: "nginx", tag: "latest", want: true},
+		{image: "registry.example.com:5000/team/app", tag: "latest", want: true},
+		{image: "registry.example.com:5000/team/app", tag: "5000", want: false},
+		{image: "nginx@" + digest, tag: "latest", want: false},
+		{image: "nginx:1.27@" + digest, tag: "1.27", want: true},
+		{image: "Invalid:Image", tag: "Image", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image+"="+tt.tag, func(t *testing.T) {
+			pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: tt.image}}}}
+			assert.Equal(t, tt.want, hasImageTag(pod, tt.tag))
+		})
+	}
+
+	initOnly := &v1.Pod{Spec: v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "migrate", Image: "ghcr.io/org/migrate"}},
+		Containers:     []v1.Container{{Name: "app", Image: "nginx:1.27"}},
+	}}
+	assert.True(t, hasImageTag(initOnly, "latest"), "init containers are checked too")
+}
+
+func TestImagesPinnedByDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		name   string
+		images []string
+		want   bool
+	}{
+		{name: "tag only", images: []string{"nginx:1.27"}, want: false},
+		{name: "no tag", images: []string{"nginx"}, want: false},
+		{name: "digest", images: []string{"nginx@" + digest}, want: true},
+		{name: "tag and digest", images: []string{"ghcr.io/org/app:v1@" + digest}, want: true},
+		{name: "one of containers by tag", images: []string{"nginx@" + digest, "busybox:1.36"}, want: false},
+		{name: "unparsable", images: []string{"Invalid:Image"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{}
+			for _, image := range tt.images {
+				pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: "app", Image: image})
+			}
+			assert.Equal(t, tt.want, imagesPinnedByDigest(pod))
+		})
+	}
+
+	initByTag := &v1.Pod{Spec: v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "migrate", Image: "ghcr.io/org/migrate:v1"}},
+		Containers:     []v1.Container{{Name: "app", Image: "nginx@" + digest}},
+	}}
+	assert.False(t, imagesPinnedByDigest(initByTag), "init containers are checked too")
+}
+
+func TestPodMatcher_Conditions(t *testing.T) {
+	pod := &v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{
+		{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+		{Type: v1.PodReady, Status: v1.ConditionFalse},
+	}}}
+	match := func(conditions ...NodeCondition) bool {
+		return (&PodHandler{}).getMatcher(ActionOptions{Conditions: conditions})(pod)
+	}
+
+	assert.True(t, match(NodeCondition{Type: "Ready", Status: "False"}))
+	assert.True(t, match(NodeCondition{Type: "ready", Status: AnyConditionStatus}))
+	assert.True(t, match(NodeCondition{Type: "PodScheduled", Status: "True"}, NodeCondition{Type: "Ready", Status: "False"}))
+	assert.False(t, match(NodeCondition{Type: "Ready", Status: "True"}))
+	assert.False(t, match(NodeCondition{Type: "Initialized", Status: AnyConditionStatus}))
+}
+
+func TestPodMatcher_Controller(t *testing.T) {
+	controller := true
+	pods := map[string]*v1.Pod{
+		"controlled": {ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: "web-6d4cf56db6", Controller: &controller},
+		}}},
+		// owned, e.g. garbage collected with a config map, but nothing recreates it
+		"owned without controller": {ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ConfigMap", Name: "debug"},
+		}}},
+		"without owners": {},
+	}
+	matching := func(options ActionOptions) []string {
+		var names []string
+		for name, pod := range pods {
+			if (&PodHandler{}).getMatcher(options)(pod) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	assert.Equal(t, []string{"controlled"}, matching(ActionOptions{Controlled: true}))
+	assert.Equal(t, []string{"owned without controller", "without owners"}, matching(ActionOptions{Standalone: true}))
+	assert.Len(t, matching(ActionOptions{}), len(pods))
 }
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+)
+
+// ProfilePhaseDiscovery is the phase of resolving the resource type and creating clients.
+// Other phases are named as the progress phases.
+const ProfilePhaseDiscovery = "discovery"
+
+// Profiler records how long each phase took and how many objects it processed.
+type Profiler struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	start  time.Time
+	phases []*phaseProfile // in order of first use
+}
+
+type phaseProfile struct {
+	name     string
+	duration time.Duration
+	objects  int
+}
+
+// NewProfiler creates a profiler; the total duration is measured from this call.
+func NewProfiler() *Profiler {
+	return newProfiler(time.Now)
+}
+
+func newProfiler(now func() time.Time) *Profiler {
+	return &Profiler{now: now, start: now()}
+}
+
+// Start starts timing the phase. The returned function stops the timer and records the number of processed objects.
+// Repeated phases, e.g. listing in several contexts, are summed up.
+func (p *Profiler) Start(phase string) func(objects int) {
+	if p == nil {
+		return func(int) {}
+	}
+	started := p.now()
+	return func(objects int) {
+		elapsed := p.now().Sub(started)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, existing := range p.phases {
+			if existing.name == phase {
+				existing.duration += elapsed
+				existing.objects += objects
+				return
+			}
+		}
+		p.phases = append(p.phases, &phaseProfile{name: phase, duration: elapsed, objects: objects})
+	}
+}
+
+// Print writes the recorded phases and the total duration as a table.
+func (p *Profiler) Print(out io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	data := make([][]string, 0, len(p.phases)+1)
+	for _, phase := range p.phases {
+		objects := "-"
+		if phase.name != ProfilePhaseDiscovery {
+			objects = strconv.Itoa(phase.objects)
+		}
+		data = append(data, []string{phase.name, phase.duration.Round(time.Millisecond).String(), objects})
+	}
+	data = append(data, []string{"total", p.now().Sub(p.start).Round(time.Millisecond).String(), "-"})
+	return printers.RenderTable(out, []string{"PHASE", "DURATION", "OBJECTS"}, data)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestProfiler(t *testing.T) {
+	clock := time.Unix(0, 0)
+	profiler := newProfiler(func() time.Time { return clock })
+
+	stop := profiler.Start(ProfilePhaseDiscovery)
+	clock = clock.Add(120 * time.Millisecond)
+	stop(0)
+
+	// repeated phases are summed up
+	for _, objects := range []int{500, 300} {
+		stop = profiler.Start(ProgressPhaseListing)
+		clock = clock.Add(time.Second)
+		stop(objects)
+	}
+
+	stop = profiler.Start(ProgressPhaseDeleting)
+	clock = clock.Add(1500 * time.Millisecond)
+	stop(3)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, profiler.Print(out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	got := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		got = append(got, strings.Fields(line))
+	}
+	assert.Equal(t, [][]string{
+		{"PHASE", "DURATION", "OBJECTS"},
+		{"discovery", "120ms", "-"},
+		{"listing", "2s", "800"},
+		{"deleting", "1.5s", "3"},
+		{"total", "3.62s", "-"},
+	}, got)
+}
+
+func TestProfiler_Nil(t *testing.T) {
+	var profiler *Profiler
+	assert.NotPanics(t, func() {
+		profiler.Start(ProgressPhaseListing)(10)
+	})
+}
+
+func TestPodHandler_Profile(t *testing.T) {
+	handler := PodHandler{
+		clientSet: fake.NewClientset(
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		),
+	}
+	profiler := NewProfiler()
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionDelete,
+		SkipConfirm: true,
+		Streams:     &streams,
+		Profiler:    profiler,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, profiler.phases, 2)
+	assert.Equal(t, ProgressPhaseListing, profiler.phases[0].name)
+	assert.Equal(t, 2, profiler.phases[0].objects)
+	assert.Equal(t, ProgressPhaseDeleting, profiler.phases[1].name)
+	assert.Equal(t, 2, profiler.phases[1].objects)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Progress phases reported while handling an action.
+const (
+	ProgressPhaseListing    = "listing"
+	ProgressPhaseDeleting   = "deleting"
+	ProgressPhasePatching   = "patching"
+	ProgressPhaseAnnotating = "annotating"
+	ProgressPhaseExecuting  = "executing"
+)
+
+// ProgressEvent describes progress of a long running operation.
+// Listing reports fetched pages, actions report processed resources out of the total.
+type ProgressEvent struct {
+	Phase     string `json:"phase"`
+	PagesDone int    `json:"pages_done,omitempty"`
+	Listed    int    `json:"listed,omitempty"`
+	Done      int    `json:"done,omitempty"`
+	Total     int    `json:"total,omitempty"`
+}
+
+// ProgressReporter receives progress events from pagination and action loops.
+type ProgressReporter interface {
+	Report(event ProgressEvent)
+}
+
+type jsonProgressReporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONProgressReporter creates a reporter writing each event as a JSON line (NDJSON) to out.
+func NewJSONProgressReporter(out io.Writer) ProgressReporter {
+	return &jsonProgressReporter{encoder: json.NewEncoder(out)}
+}
+
+func (r *jsonProgressReporter) Report(event ProgressEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.encoder.Encode(event) // progress is best effort and must not fail the action
+}
+
+// reportProgress sends the event to the progress reporter, if any.
+func (o ActionOptions) reportProgress(event ProgressEvent) {
+	if o.Progress != nil {
+		o.Progress.Report(event)
+	}
+}
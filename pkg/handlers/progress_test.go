@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type recordingProgressReporter struct {
+	events []ProgressEvent
+}
+
+func (r *recordingProgressReporter) Report(event ProgressEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestJSONProgressReporter(t *testing.T) {
+	out := &bytes.Buffer{}
+	reporter := NewJSONProgressReporter(out)
+
+	reporter.Report(ProgressEvent{Phase: ProgressPhaseListing, PagesDone: 3, Listed: 1500})
+	reporter.Report(ProgressEvent{Phase: ProgressPhaseDeleting, Done: 120, Total: 500})
+
+	assert.Equal(t, `{"phase":"listing","pages_done":3,"listed":1500}`+"\n"+
+		`{"phase":"deleting","done":120,"total":500}`+"\n", out.String())
+}
+
+func TestUniversalHandler_ReportsProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default"}},
+	)
+	handler := NewUniversalHandler(UniversalHandlerOptions{
+		Client:   client,
+		Resource: getResource("configmap"),
+	})
+
+	reporter := &recordingProgressReporter{}
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:   "default",
+		Action:      ActionDelete,
+		SkipConfirm: true,
+		Streams:     &streams,
+		Progress:    reporter,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ProgressEvent{
+		{Phase: ProgressPhaseListing, PagesDone: 1, Listed: 2},
+		{Phase: ProgressPhaseDeleting, Done: 1, Total: 2},
+		{Phase: ProgressPhaseDeleting, Done: 2, Total: 2},
+	}, reporter.events)
+}
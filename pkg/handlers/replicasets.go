@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// revisionAnnotation is set by the deployment controller on deployments and their replica sets.
+// A replica set is the current revision of its deployment when both have the same value.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// IsScalableType returns true if the resource type supports zero replicas filtering.
+func IsScalableType(gvr schema.GroupVersionResource) bool {
+	switch gvr {
+	case DeploymentType, StatefulSetType, ReplicaSetType:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasZeroReplicas returns true if the workload is scaled to zero and has no replicas left.
+// A missing spec.replicas defaults to one replica.
+func hasZeroReplicas(resource unstructured.Unstructured) bool {
+	desired, found, err := unstructured.NestedInt64(resource.Object, "spec", "replicas")
+	if err != nil || !found || desired != 0 {
+		return false
+	}
+	current, _, _ := unstructured.NestedInt64(resource.Object, "status", "replicas")
+	return current == 0
+}
+
+// withoutCurrentReplicaSets drops replica sets that are the current revision of their deployment,
+// so only the rollout history left behind by previous revisions is kept.
+// Replica sets whose deployment no longer exists are kept as well.
+func (h *UniversalHandler) withoutCurrentReplicaSets(
+	ctx context.Context,
+	replicaSets []unstructured.Unstructured,
+) ([]unstructured.Unstructured, error) {
+	revisions := make(map[string]string)
+	kept := make([]unstructured.Unstructured, 0, len(replicaSets))
+	for _, replicaSet := range replicaSets {
+		owner := metav1.GetControllerOf(&replicaSet)
+		if owner == nil || owner.Kind != "Deployment" {
+			kept = append(kept, replicaSet)
+			continue
+		}
+
+		key := replicaSet.GetNamespace() + "/" + owner.Name
+		revision, cached := revisions[key]
+		if !cached {
+			deployment, err := h.opts.Client.Resource(DeploymentType).
+				Namespace(replicaSet.GetNamespace()).
+				Get(ctx, owner.Name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+			case err != nil:
+				return nil, fmt.Errorf("failed to get deployment %s: %w", key, err)
+			default:
+				revision = deployment.GetAnnotations()[revisionAnnotation]
+			}
+			revisions[key] = revision
+		}
+
+		if revision == "" || replicaSet.GetAnnotations()[revisionAnnotation] != revision {
+			kept = append(kept, replicaSet)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func replicaSet(name, owner, revision string, replicas int32) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{revisionAnnotation: revision},
+		},
+		Spec:   appsv1.ReplicaSetSpec{Replicas: &replicas},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+	}
+	if owner != "" {
+		controller := true
+		rs.OwnerReferences = []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: owner, Controller: &controller},
+		}
+	}
+	return rs
+}
+
+func TestHasZeroReplicas(t *testing.T) {
+	assert.True(t, hasZeroReplicas(toUnstructured(t, replicaSet("web-1", "", "1", 0))))
+	assert.False(t, hasZeroReplicas(toUnstructured(t, replicaSet("web-2", "", "2", 2))))
+
+	scalingDown := replicaSet("web-3", "", "3", 0)
+	scalingDown.Status.Replicas = 1
+	assert.False(t, hasZeroReplicas(toUnstructured(t, scalingDown)), "replicas are still terminating")
+
+	defaulted := replicaSet("web-4", "", "4", 0)
+	defaulted.Spec.Replicas = nil
+	assert.False(t, hasZeroReplicas(toUnstructured(t, defaulted)), "missing spec.replicas defaults to one")
+}
+
+func TestUniversalHandler_ZeroReplicaSets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	zero := int32(0)
+	web := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Annotations: map[string]string{revisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &zero},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme,
+		web,
+		replicaSet("web-1", "web", "1", 0),
+		replicaSet("web-2", "web", "2", 2),
+		replicaSet("web-3", "web", "3", 0),
+		replicaSet("removed-1", "removed", "1", 0),
+		replicaSet("standalone", "", "", 0),
+	)
+
+	replicaSetType := Resource{
+		GroupVersionResource: ReplicaSetType,
+		GroupVersionKind:     appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+		SingularName:         "replicaset",
+		PluralName:           "replicasets",
+		IsNamespaced:         true,
+	}
+	handler, err := GetResourceHandler(replicaSetType, NewHandlerOptions().
+		WithDynamic(dynamicClient).
+		WithNameOutput(true))
+	require.NoError(t, err)
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err = handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:    "default",
+		Action:       ActionList,
+		ResourceType: replicaSetType,
+		ZeroReplicas: true,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "replicaset.apps/removed-1\nreplicaset.apps/standalone\nreplicaset.apps/web-1\n", out.String(),
+		"web-3 is the current revision of web and web-2 still has replicas")
+}
@@ -2,17 +2,26 @@ package handlers
 
 import (
 	"context"
+	"io"
+	"net"
 	"regexp"
+	"sort"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/alikhil/kubectl-find/pkg/printers"
 	"github.com/itchyny/gojq"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8s_types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 )
 
 type Resource struct {
@@ -23,6 +32,15 @@ type Resource struct {
 	IsNamespaced bool
 }
 
+// nameQualifier returns the lowercase kind with its group, as used by `kubectl get -o name`.
+func (r Resource) nameQualifier() string {
+	kind := strings.ToLower(r.Kind)
+	if r.GroupVersionKind.Group == "" {
+		return kind
+	}
+	return kind + "." + r.GroupVersionKind.Group
+}
+
 //nolint:gochecknoglobals
 var PodType = schema.GroupVersionResource{
 	Resource: "pods",
@@ -65,6 +83,13 @@ var DaemonSetType = schema.GroupVersionResource{
 	Version:  "v1",
 }
 
+//nolint:gochecknoglobals
+var JobType = schema.GroupVersionResource{
+	Resource: "jobs",
+	Group:    "batch",
+	Version:  "v1",
+}
+
 //nolint:gochecknoglobals
 var NodeType = schema.GroupVersionResource{
 	Resource: "nodes",
@@ -87,6 +112,7 @@ const (
 	ActionPatch
 	ActionExec
 	ActionAnnotate
+	ActionExecEach
 )
 
 func (a Action) String() string {
@@ -101,6 +127,8 @@ func (a Action) String() string {
 		return "exec"
 	case ActionAnnotate:
 		return "annotate"
+	case ActionExecEach:
+		return "exec-each"
 	default:
 		return "Unknown"
 	}
@@ -115,12 +143,32 @@ type HandlerOptions struct {
 	clientSet      kubernetes.Interface
 	executorGetter ExecutorGetter
 	dynamic        dynamic.Interface
+	restMapper     meta.RESTMapper // maps owner kinds to resources with --tree
 	allNamespaces  bool
 	restarted      bool
 	withImages     bool
 	labels         []string
 	nodeLabels     []string
 	annotations    []string
+	health         bool
+	serverColumns  bool
+	listOptions    metav1.ListOptions // options resources are listed with, server tables are requested with them
+	showNamespace  *bool              // overrides automatic NAMESPACE column visibility when set
+	customColumns  []printers.Column
+	wide           bool
+	selectColumns  []string
+	hideColumns    []string
+	contextName    string              // prefixes rows with a CONTEXT column when set
+	tableRows      *printers.TableRows // collects table rows instead of rendering them when set
+	showOwner      bool
+	tree           bool
+	nameOutput     bool
+	rawOutput      bool
+	jsonLines      bool
+	flattenToPods  bool
+	showConditions bool
+	canonicalOrder bool
+	alsoJSON       io.Writer // receives matched objects as JSON lines in addition to the chosen output when set
 }
 
 func NewHandlerOptions() HandlerOptions {
@@ -157,6 +205,11 @@ func (o HandlerOptions) WithDynamic(dynamic dynamic.Interface) HandlerOptions {
 	return o
 }
 
+func (o HandlerOptions) WithRESTMapper(restMapper meta.RESTMapper) HandlerOptions {
+	o.restMapper = restMapper
+	return o
+}
+
 func (o HandlerOptions) WithLabels(withLabels []string) HandlerOptions {
 	o.labels = withLabels
 	return o
@@ -172,43 +225,273 @@ func (o HandlerOptions) WithAnnotations(withAnnotations []string) HandlerOptions
 	return o
 }
 
+func (o HandlerOptions) WithHealth(health bool) HandlerOptions {
+	o.health = health
+	return o
+}
+
+func (o HandlerOptions) WithServerColumns(serverColumns bool) HandlerOptions {
+	o.serverColumns = serverColumns
+	return o
+}
+
+func (o HandlerOptions) WithShowNamespace(showNamespace bool) HandlerOptions {
+	o.showNamespace = &showNamespace
+	return o
+}
+
+func (o HandlerOptions) WithCustomColumns(customColumns []printers.Column) HandlerOptions {
+	o.customColumns = customColumns
+	return o
+}
+
+func (o HandlerOptions) WithWide(wide bool) HandlerOptions {
+	o.wide = wide
+	return o
+}
+
+func (o HandlerOptions) WithSelectColumns(selectColumns []string) HandlerOptions {
+	o.selectColumns = selectColumns
+	return o
+}
+
+func (o HandlerOptions) WithHideColumns(hideColumns []string) HandlerOptions {
+	o.hideColumns = hideColumns
+	return o
+}
+
+func (o HandlerOptions) WithShowOwner(showOwner bool) HandlerOptions {
+	o.showOwner = showOwner
+	return o
+}
+
+func (o HandlerOptions) WithShowConditions(showConditions bool) HandlerOptions {
+	o.showConditions = showConditions
+	return o
+}
+
+func (o HandlerOptions) WithTree(tree bool) HandlerOptions {
+	o.tree = tree
+	return o
+}
+
+func (o HandlerOptions) WithNameOutput(nameOutput bool) HandlerOptions {
+	o.nameOutput = nameOutput
+	return o
+}
+
+func (o HandlerOptions) WithRawOutput(rawOutput bool) HandlerOptions {
+	o.rawOutput = rawOutput
+	return o
+}
+
+func (o HandlerOptions) WithJSONLines(jsonLines bool) HandlerOptions {
+	o.jsonLines = jsonLines
+	return o
+}
+
+func (o HandlerOptions) WithCanonicalOrder(canonicalOrder bool) HandlerOptions {
+	o.canonicalOrder = canonicalOrder
+	return o
+}
+
+func (o HandlerOptions) WithAlsoJSON(out io.Writer) HandlerOptions {
+	o.alsoJSON = out
+	return o
+}
+
+func (o HandlerOptions) WithFlattenToPods(flattenToPods bool) HandlerOptions {
+	o.flattenToPods = flattenToPods
+	return o
+}
+
+func (o HandlerOptions) WithContextName(contextName string) HandlerOptions {
+	o.contextName = contextName
+	return o
+}
+
+func (o HandlerOptions) WithListOptions(listOptions metav1.ListOptions) HandlerOptions {
+	o.listOptions = listOptions
+	return o
+}
+
+func (o HandlerOptions) WithTableRows(tableRows *printers.TableRows) HandlerOptions {
+	o.tableRows = tableRows
+	return o
+}
+
+// prefixColumns returns columns printed before all others.
+func (o HandlerOptions) prefixColumns() []printers.Column {
+	if o.contextName == "" {
+		return nil
+	}
+	return []printers.Column{
+		{
+			Header: "CONTEXT",
+			Value: func(unstructured.Unstructured) string {
+				return o.contextName
+			},
+		},
+	}
+}
+
+// namespaceColumnVisible returns whether the NAMESPACE column should be printed,
+// honoring an explicit override over the automatic value.
+func (o HandlerOptions) namespaceColumnVisible(automatic bool) bool {
+	if o.showNamespace != nil {
+		return *o.showNamespace
+	}
+	return automatic
+}
+
+// newServerTablePrinter creates a printer that renders the columns defined by the API server.
+func newServerTablePrinter(opts HandlerOptions, resource Resource, showNamespace bool) printers.BatchPrinter {
+	return printers.NewServerTablePrinter(printers.ServerTablePrinterOptions{
+		PrefixColumns:     opts.prefixColumns(),
+		ShowNamespace:     showNamespace,
+		Wide:              opts.wide,
+		Fetcher:           newServerTableFetcher(opts.clientSet.Discovery().RESTClient(), resource, opts.listOptions),
+		LabelColumns:      GetLabelColumns(opts, resource.GroupVersionResource),
+		AnnotationColumns: GetAnnotationColumns(opts),
+		Rows:              opts.tableRows,
+	})
+}
+
+// newPrinter creates the printer for matched resources according to the handler options.
+// With --also-json, matched objects are written as JSON lines to the extra output too.
+func newPrinter(
+	opts HandlerOptions,
+	resource Resource,
+	tableOptions printers.TablePrinterOptions,
+) (printers.BatchPrinter, error) {
+	jsonOptions := printers.JSONPrinterOptions{
+		GroupVersionKind: resource.GroupVersionKind,
+		CanonicalOrder:   opts.canonicalOrder,
+	}
+	printer, err := newOutputPrinter(opts, resource, jsonOptions, tableOptions)
+	if err != nil || opts.alsoJSON == nil {
+		return printer, err
+	}
+	return printers.NewMultiPrinter(
+		printers.Sink{Printer: printer},
+		printers.Sink{Printer: printers.NewJSONLinesPrinter(jsonOptions), Out: opts.alsoJSON},
+	), nil
+}
+
+// newOutputPrinter creates the printer for the output format chosen with --output and related flags.
+func newOutputPrinter(
+	opts HandlerOptions,
+	resource Resource,
+	jsonOptions printers.JSONPrinterOptions,
+	tableOptions printers.TablePrinterOptions,
+) (printers.BatchPrinter, error) {
+	if opts.jsonLines {
+		return printers.NewJSONLinesPrinter(jsonOptions), nil
+	}
+	if opts.rawOutput {
+		return printers.NewRawPrinter(jsonOptions), nil
+	}
+	if opts.nameOutput {
+		return printers.NewNamePrinter(printers.NamePrinterOptions{Resource: resource.nameQualifier()}), nil
+	}
+	if opts.tree {
+		return printers.NewTreePrinter(printers.TreePrinterOptions{
+			Kind:        resource.Kind,
+			OwnerGetter: newOwnerGetter(opts.dynamic, opts.restMapper),
+		}), nil
+	}
+	if opts.serverColumns {
+		return newServerTablePrinter(opts, resource, tableOptions.ShowNamespace), nil
+	}
+	tableOptions.PrefixColumns = opts.prefixColumns()
+	tableOptions.CustomColumns = opts.customColumns
+	tableOptions.SelectColumns = opts.selectColumns
+	tableOptions.HideColumns = opts.hideColumns
+	tableOptions.Rows = opts.tableRows
+	if err := tableOptions.Validate(); err != nil {
+		return nil, err
+	}
+	return printers.NewTablePrinter(tableOptions), nil
+}
+
+// newPodPrinter creates the printer for pods, used for matched pods and pods of workloads flattened to pods.
+func newPodPrinter(opts HandlerOptions, resource Resource) (printers.BatchPrinter, error) {
+	return newPrinter(opts, resource, printers.TablePrinterOptions{
+		ShowNamespace:     opts.namespaceColumnVisible(opts.allNamespaces),
+		AdditionalColumns: GetColumnsFor(opts, resource),
+		SuffixColumns:     append(GetOwnerColumns(opts), GetConditionsColumns(opts)...),
+		LabelColumns:      GetLabelColumns(opts, resource.GroupVersionResource),
+		AnnotationColumns: GetAnnotationColumns(opts),
+	})
+}
+
 func GetResourceHandler(resource Resource, opts HandlerOptions) (ResourceHandler, error) {
 	switch resource.GroupVersionResource {
 	case PodType:
+		printer, err := newPodPrinter(opts, resource)
+		if err != nil {
+			return nil, err
+		}
 		return &PodHandler{
-			clientSet: opts.clientSet,
-			printer: printers.NewTablePrinter(printers.TablePrinterOptions{
-				ShowNamespace:     opts.allNamespaces,
-				AdditionalColumns: GetColumnsFor(opts, resource),
-				LabelColumns:      GetLabelColumns(opts, resource.GroupVersionResource),
-				AnnotationColumns: GetAnnotationColumns(opts),
-			}),
+			clientSet:      opts.clientSet,
+			printer:        printer,
 			executorGetter: opts.executorGetter,
+			nameOutput:     opts.nameOutput && opts.alsoJSON == nil, // the shortcut would skip the JSON output
 		}, nil
 	default:
+		suffixColumns := GetSuffixColumnsFor(resource)
+		if opts.health && IsWorkloadType(resource.GroupVersionResource) {
+			suffixColumns = append(suffixColumns, getHealthColumns(resource.GroupVersionResource)...)
+		}
+		suffixColumns = append(suffixColumns, GetOwnerColumns(opts)...)
+		suffixColumns = append(suffixColumns, GetConditionsColumns(opts)...)
+
+		printer, err := newPrinter(opts, resource, printers.TablePrinterOptions{
+			ShowNamespace:     opts.namespaceColumnVisible(resource.IsNamespaced && opts.allNamespaces),
+			AdditionalColumns: GetColumnsFor(opts, resource),
+			SuffixColumns:     suffixColumns,
+			LabelColumns:      GetLabelColumns(opts, resource.GroupVersionResource),
+			AnnotationColumns: GetAnnotationColumns(opts),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var flattened *workloadPods
+		if opts.flattenToPods && IsWorkloadType(resource.GroupVersionResource) {
+			// pods of several workloads share no selectors to request server tables with
+			podPrinter, podPrinterErr := newPodPrinter(opts.WithListOptions(metav1.ListOptions{}), podResource)
+			if podPrinterErr != nil {
+				return nil, podPrinterErr
+			}
+			flattened = &workloadPods{clientSet: opts.clientSet, printer: podPrinter}
+		}
 
 		return NewUniversalHandler(UniversalHandlerOptions{
-			Client: opts.dynamic,
-			Printer: printers.NewTablePrinter(printers.TablePrinterOptions{
-				ShowNamespace:     resource.IsNamespaced && opts.allNamespaces,
-				AdditionalColumns: GetColumnsFor(opts, resource),
-				SuffixColumns:     GetSuffixColumnsFor(resource),
-				LabelColumns:      GetLabelColumns(opts, resource.GroupVersionResource),
-				AnnotationColumns: GetAnnotationColumns(opts),
-			}),
+			Client:          opts.dynamic,
+			Printer:         printer,
 			Resource:        resource,
 			ResourceMatcher: getResourceMatcher(resource),
+			WorkloadPods:    flattened,
 		}), nil
 	}
 }
 
+// ActionOptions configures how a handler finds resources and what it does with the matched ones.
+// The recorders Profiler, Stats, ByNamespace and Audit are optional: their methods do nothing on a nil receiver,
+// so handlers call them unconditionally.
 type ActionOptions struct {
 	Namespace       string
 	LabelSelector   string
+	FieldSelector   string             // passed to the API server as is, e.g. status.phase=Running
+	ListOptions     metav1.ListOptions // base options of list calls given by --list-options, selectors set with flags win
 	Action          Action
 	NameRegex       *regexp.Regexp
+	NameExclude     []*regexp.Regexp // exclude resources whose names match any of these, applied after NameRegex
 	MinAge          time.Duration
 	MaxAge          time.Duration
+	CreatedBefore   time.Time   // find resources created before this time, e.g. of the object given by --older-than
+	CreatedAfter    time.Time   // find resources created after this time, e.g. of the object given by --newer-than
 	SkipConfirm     bool        // skip confirmation prompt before performing actions
 	Force           bool        // immediately remove resources from API and bypass graceful deletion (only for delete action)
 	ResourceType    Resource    // type of resource being handled
@@ -216,24 +499,149 @@ type ActionOptions struct {
 	ShowLabels      []string    // list of labels to show in output
 	ShowAnnotations []string    // list of annotations to show in output
 	NaturalSort     bool        // sort resource names in natural order
+	SortBy          string      // sort order of resources, one of the SortBy constants; takes precedence over NaturalSort
+	ReverseSort     bool        // reverse the order of NaturalSort or SortBy
+	Watch           bool        // after listing, print matching changes until the watch ends, only for list action
+	WatchOnly       bool        // with Watch, print only changes and not the listed resources
+	SaveTo          string      // directory to save matched resources to as cleaned YAML before the action
+	Stale           bool        // find resources whose metadata.generation differs from status.observedGeneration
+	Phase           string      // find resources other than pods by status.phase, e.g. "Bound"; compared case-insensitively
+	IgnoreForbidden bool        // with all namespaces, search namespaces one by one and skip forbidden ones if listing all is forbidden
+
+	// FallbackNamespace is searched with IgnoreForbidden when namespaces cannot be listed either,
+	// usually the namespace of the kubeconfig context.
+	FallbackNamespace string
 
 	// Annotate action options
 	Annotate AnnotateConfig // parsed annotation additions and removals
 
+	// Exec-each action options
+	ExecEach CommandTemplate // local command rendered for every matched object, run without a shell
+
 	// Pod related options
-	PodStatus      v1.PodPhase // only for pods, e.g. "Running", "Pending", etc.
-	Patch          string
-	PatchStrategy  k8s_types.PatchType // type of patch to apply, e.g. "json", "merge", etc.
-	Exec           string              // command to execute on pods
-	NodeNameRegex  *regexp.Regexp      // filter pods by node name, only applicable for pod resources
-	Restarted      bool                // only for pods, find pods that have been restarted at least once
-	ImageRegex     *regexp.Regexp      // filter pods by container image, only applicable for pod resources
-	ShowNodeLabels []string            // list of node labels to show, only applicable for pod resources
+	PodStatus       v1.PodPhase // only for pods, e.g. "Running", "Pending", etc.
+	Patch           string
+	PatchTemplate   *template.Template  // template rendering the patch for every object, used instead of Patch when set
+	Diff            bool                // print a diff of the patched resources before applying the patch
+	PreviewDiff     bool                // print diffs of the first few patched resources in the confirmation prompt
+	PatchStrategy   k8s_types.PatchType // type of patch to apply, e.g. "json", "merge", etc.
+	ForceConflicts  bool                // take ownership of fields managed by other field managers, only for apply patches
+	Exec            string              // command to execute on pods
+	DryRun          bool                // print pods the command would be executed on without executing it, only for exec action
+	Count           bool                // print the number of pods the command would be executed on, only for exec action
+	IncludeNotReady bool                // execute the command on pods that are not ready too, only for exec action
+	NodeNameRegex   *regexp.Regexp      // filter pods by node name, only applicable for pod resources
+	NodeName        string              // filter pods by exact spec.nodeName, pushed down as a field selector
+	PodIP           net.IP              // filter pods with this IP address, only for pods
+	PodIPRange      *net.IPNet          // filter pods with an IP address in this network, only for pods
+	HostIP          net.IP              // filter pods running on the node with this IP address, only for pods
+	Reschedulable   bool                // find pods a node drain would move, only for pods
+	Standalone      bool                // find pods without a controller owner reference, only for pods
+	Controlled      bool                // find pods with a controller owner reference, only for pods
+	Evict           bool                // delete pods with the eviction API respecting disruption budgets
+	EvictTimeout    time.Duration       // how long to retry evictions blocked by a disruption budget
+	Restarted       bool                // only for pods, find pods that have been restarted at least once
+	MinRestarts     int32               // only for pods, find pods whose containers have restarted at least this many times in total
+	RestartReason   string              // only for pods, find pods with a container whose last termination has this reason
+	ExitCode        *int32              // only for pods, find pods with a container whose last termination exited with this code
+	ExitCodeNonzero bool                // only for pods, find pods with a container whose last termination exited with a nonzero code
+	ImageRegex      *regexp.Regexp      // filter pods by container image, only applicable for pod resources
+	ContainerRegex  *regexp.Regexp      // filter pods by container or init container name, only applicable for pod resources
+	ImageRegistry   string              // filter pods by the registry host of container images, only applicable for pod resources
+	ImageTag        string              // filter pods by the tag of container images, only applicable for pod resources
+	ByDigest        bool                // find pods with all container images referenced by digest, only applicable for pod resources
+	NoDigest        bool                // find pods with a container image referenced without digest, only applicable for pod resources
+	EnvVars         []EnvVarFilter      // filter pods by declared environment variables, only applicable for pod resources
+	VolumeType      string              // filter pods by volume type, e.g. "hostPath", only applicable for pod resources
+	VolumeName      string              // filter pods by volume name or the name of the secret, config map or claim it uses
+	UsesSecret      string              // filter pods referencing the secret in volumes, env or image pull secrets
+	UsesPVC         string              // filter pods mounting the persistent volume claim
+	UsesConfigMap   string              // filter pods referencing the config map in volumes or env
+	RequiredLabel   NodeLabel           // filter pods whose node selector or required node affinity demands the label
+	ShowNodeLabels  []string            // list of node labels to show, only applicable for pod resources
+	ListImages      bool                // print distinct images of matched pods instead of pods, only for list action
+	HintOnEmpty     bool                // print label keys of unfiltered resources when the label selector matches nothing
+	RestartTimeline bool                // print container terminations of the single matched pod, only for list action
+	Colocated       bool                // print nodes hosting several matched pods of the same controller, only for list action
+
+	// Label comparison options
+	LabelCompare []LabelComparison // filter resources by comparing label values as numbers, all must match
+
+	// Condition related options
+	Conditions    []NodeCondition // filter resources by status.conditions, AnyConditionStatus matches any status of the type
+	ConditionAges []ConditionAge  // filter resources whose condition has had a status for at least a duration
 
 	// Node related options
 	NodeConditions []NodeCondition // filter nodes by conditions, only applicable for node resources
 
-	Streams *genericclioptions.IOStreams
+	// Workload related options
+	Health       bool // only for deployments, statefulsets and daemonsets, find workloads that are not fully available
+	ZeroReplicas bool // only for deployments, statefulsets and replicasets, find workloads scaled to zero; skips current replica sets
+
+	// Job related options
+	Completed bool // only for jobs, find jobs that have completed
+	FailedJob bool // only for jobs, find jobs that have failed
+
+	Streams     *genericclioptions.IOStreams
+	Progress    ProgressReporter // optional receiver of machine-readable progress events
+	Profiler    *Profiler        // optional recorder of phase timings
+	Stats       *MatchStats      // optional counter of fetched and matched objects
+	ByNamespace *NamespaceCounts // optional tally of matched objects per namespace
+	Audit       *AuditTrail      // optional recorder of matched objects and outcomes of the action on them
+}
+
+// createdBetween returns true if the creation time is within the CreatedBefore and CreatedAfter bounds that are set.
+func createdBetween(created time.Time, options ActionOptions) bool {
+	if !options.CreatedBefore.IsZero() && !created.Before(options.CreatedBefore) {
+		return false
+	}
+	if !options.CreatedAfter.IsZero() && !created.After(options.CreatedAfter) {
+		return false
+	}
+	return true
+}
+
+// nameExcluded returns true if the name matches any of the exclude regular expressions.
+func nameExcluded(name string, excludes []*regexp.Regexp) bool {
+	for _, exclude := range excludes {
+		if exclude.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort orders accepted by the --sort flag. Restarts and status are only supported for pods.
+const (
+	SortByAge      = "age"      // oldest first
+	SortByRestarts = "restarts" // most restarted first
+	SortByStatus   = "status"   // by pod phase
+)
+
+// sortMatched sorts matched resources in the order requested by the options, keeping the listed order otherwise.
+// bySortKey provides the ordering for each supported SortBy value.
+func sortMatched(options ActionOptions, byName sort.Interface, bySortKey map[string]sort.Interface) {
+	var data sort.Interface
+	switch {
+	case options.SortBy != "":
+		data = bySortKey[options.SortBy]
+	case options.NaturalSort:
+		data = byName
+	}
+	if data == nil {
+		return
+	}
+	if options.ReverseSort {
+		data = sort.Reverse(data)
+	}
+	sort.Sort(data)
+}
+
+// logMatch logs whether an object passed the filters, at high verbosity only.
+func logMatch(kind, namespace, name string, matched bool) {
+	if logger := klog.V(5); logger.Enabled() {
+		logger.InfoS("Evaluated filters", "kind", kind, "namespace", namespace, "name", name, "matched", matched)
+	}
 }
 
 // NodeCondition represents a node condition filter with a type and expected status.
@@ -242,6 +650,19 @@ type NodeCondition struct {
 	Status string
 }
 
+// NodeLabel represents a node label with its value.
+type NodeLabel struct {
+	Key   string
+	Value string
+}
+
+// EnvVarFilter represents an environment variable filter with a name and, if HasValue is set, an expected literal value.
+type EnvVarFilter struct {
+	Name     string
+	Value    string
+	HasValue bool
+}
+
 // ResourceHandler is an interface that represents a generic resource handler.
 type ResourceHandler interface {
 	IsExecutable() bool
@@ -254,6 +675,8 @@ func getResourceMatcher(resource Resource) ResourceMatcher {
 	switch resource.GroupVersionResource {
 	case NodeType:
 		return NodeConditionMatches
+	case JobType:
+		return JobStatusMatches
 	default:
 		return nil
 	}
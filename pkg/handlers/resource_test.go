@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandlerOptions_NamespaceColumnVisible(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      HandlerOptions
+		automatic bool
+		want      bool
+	}{
+		{
+			name:      "automatic shown without override",
+			opts:      NewHandlerOptions(),
+			automatic: true,
+			want:      true,
+		},
+		{
+			name:      "automatic hidden without override",
+			opts:      NewHandlerOptions(),
+			automatic: false,
+			want:      false,
+		},
+		{
+			name:      "show-namespace overrides hidden column",
+			opts:      NewHandlerOptions().WithShowNamespace(true),
+			automatic: false,
+			want:      true,
+		},
+		{
+			name:      "no-namespace overrides shown column",
+			opts:      NewHandlerOptions().WithShowNamespace(false),
+			automatic: true,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.namespaceColumnVisible(tt.automatic))
+		})
+	}
+}
+
+func TestCreatedBetween(t *testing.T) {
+	reference := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	before, after := reference.Add(-time.Hour), reference.Add(time.Hour)
+
+	olderThan := ActionOptions{CreatedBefore: reference}
+	assert.True(t, createdBetween(before, olderThan))
+	assert.False(t, createdBetween(reference, olderThan), "the reference object itself is not older than itself")
+	assert.False(t, createdBetween(after, olderThan))
+
+	newerThan := ActionOptions{CreatedAfter: reference}
+	assert.False(t, createdBetween(before, newerThan))
+	assert.True(t, createdBetween(after, newerThan))
+
+	assert.True(t, createdBetween(before, ActionOptions{}))
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", CreationTimestamp: metav1.NewTime(before)}}
+	assert.True(t, (&PodHandler{}).getMatcher(olderThan)(pod))
+	assert.False(t, (&PodHandler{}).getMatcher(newerThan)(pod))
+}
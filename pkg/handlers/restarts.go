@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	v1 "k8s.io/api/core/v1"
+)
+
+// containerTermination is a termination of a pod container, recorded in its current or last state.
+type containerTermination struct {
+	container string
+	restarts  int32
+	state     *v1.ContainerStateTerminated
+}
+
+// restartTimeline returns the terminations of all containers of the pod, including init containers,
+// ordered by the time they finished. Only the current and the last state of a container are kept
+// by the kubelet, so older restarts are not part of the timeline.
+func restartTimeline(pod *v1.Pod) []containerTermination {
+	var terminations []containerTermination
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			for _, state := range []*v1.ContainerStateTerminated{status.LastTerminationState.Terminated, status.State.Terminated} {
+				if state == nil {
+					continue
+				}
+				terminations = append(terminations, containerTermination{
+					container: status.Name,
+					restarts:  status.RestartCount,
+					state:     state,
+				})
+			}
+		}
+	}
+	sort.SliceStable(terminations, func(i, j int) bool {
+		return terminations[i].state.FinishedAt.Before(&terminations[j].state.FinishedAt)
+	})
+	return terminations
+}
+
+// anyTermination returns true if the current or the last termination of any container of the pod,
+// including init containers, satisfies matches. Current terminations cover containers that are not
+// restarted, e.g. of failed Job pods. Containers that never terminated are skipped.
+func anyTermination(pod *v1.Pod, matches func(*v1.ContainerStateTerminated) bool) bool {
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			current, last := status.State.Terminated, status.LastTerminationState.Terminated
+			if current != nil && matches(current) || last != nil && matches(last) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasTerminationReason returns true if the current or last termination of any container of the pod
+// has the reason, e.g. OOMKilled. Reasons are compared case-insensitively.
+func hasTerminationReason(pod *v1.Pod, reason string) bool {
+	return anyTermination(pod, func(terminated *v1.ContainerStateTerminated) bool {
+		return strings.EqualFold(terminated.Reason, reason)
+	})
+}
+
+// hasTerminationExitCode returns true if the current or last termination of any container of the pod
+// exited with the code, or with any nonzero code if exitCode is nil.
+func hasTerminationExitCode(pod *v1.Pod, exitCode *int32) bool {
+	return anyTermination(pod, func(terminated *v1.ContainerStateTerminated) bool {
+		if exitCode == nil {
+			return terminated.ExitCode != 0
+		}
+		return terminated.ExitCode == *exitCode
+	})
+}
+
+// printRestartTimeline prints when and why the containers of the pod terminated, oldest first.
+func printRestartTimeline(pod *v1.Pod, out io.Writer) error {
+	terminations := restartTimeline(pod)
+	if len(terminations) == 0 {
+		_, err := fmt.Fprintf(out, "No container terminations recorded for pod %s/%s\n", pod.Namespace, pod.Name)
+		return err
+	}
+
+	data := make([][]string, len(terminations))
+	for i, termination := range terminations {
+		reason := termination.state.Reason
+		if reason == "" {
+			reason = NoneStr
+		}
+		data[i] = []string{
+			formatTerminationTime(termination.state.StartedAt.Time),
+			formatTerminationTime(termination.state.FinishedAt.Time),
+			termination.container,
+			strconv.Itoa(int(termination.restarts)),
+			reason,
+			strconv.Itoa(int(termination.state.ExitCode)),
+		}
+	}
+	return printers.RenderTable(out, []string{"STARTED", "FINISHED", "CONTAINER", "RESTARTS", "REASON", "EXIT CODE"}, data)
+}
+
+func formatTerminationTime(t time.Time) string {
+	if t.IsZero() {
+		return NoneStr
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodHandler_RestartTimeline(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2025, 3, 1, 10, minute, 0, 0, time.UTC))
+	}
+	terminated := func(reason string, exitCode int32, started, finished int) *v1.ContainerStateTerminated {
+		return &v1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode, StartedAt: at(started), FinishedAt: at(finished)}
+	}
+	crashing := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default"},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{Name: "migrate", State: v1.ContainerState{Terminated: terminated("Completed", 0, 0, 1)}},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "api",
+					RestartCount:         7,
+					State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: terminated("OOMKilled", 137, 20, 25)},
+				},
+				{
+					Name:                 "proxy",
+					RestartCount:         2,
+					State:                v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: at(15)}},
+					LastTerminationState: v1.ContainerState{Terminated: terminated("", 1, 2, 14)},
+				},
+				{Name: "metrics", State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: at(2)}}},
+			},
+		},
+	}
+	clientSet := fake.NewClientset(
+		crashing,
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+	)
+	handler := PodHandler{clientSet: clientSet}
+
+	t.Run("prints terminations of all containers oldest first", func(t *testing.T) {
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Namespace:       "default",
+			Action:          ActionList,
+			NameRegex:       regexp.MustCompile("^api-"),
+			RestartTimeline: true,
+			Streams:         &streams,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"STARTED", "FINISHED", "CONTAINER", "RESTARTS", "REASON", "EXIT", "CODE"},
+			{"2025-03-01T10:00:00Z", "2025-03-01T10:01:00Z", "migrate", "0", "Completed", "0"},
+			{"2025-03-01T10:02:00Z", "2025-03-01T10:14:00Z", "proxy", "2", NoneStr, "1"},
+			{"2025-03-01T10:20:00Z", "2025-03-01T10:25:00Z", "api", "7", "OOMKilled", "137"},
+		}, tableFields(out.String()))
+	})
+
+	t.Run("requires exactly one matching pod", func(t *testing.T) {
+		streams, _, _, _ := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Namespace:       "default",
+			Action:          ActionList,
+			RestartTimeline: true,
+			Streams:         &streams,
+		})
+		require.EqualError(t, err, "--restart-timeline requires exactly one matching pod, but found 2")
+	})
+
+	t.Run("pod without terminations", func(t *testing.T) {
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		err := handler.HandleAction(t.Context(), ActionOptions{
+			Namespace:       "default",
+			Action:          ActionList,
+			NameRegex:       regexp.MustCompile("^web-"),
+			RestartTimeline: true,
+			Streams:         &streams,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "No container terminations recorded for pod default/web-1\n", out.String())
+	})
+}
+
+func TestPodMatcher_RestartReason(t *testing.T) {
+	lastTerminated := func(reason string) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			Name:                 "app",
+			RestartCount:         3,
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: reason}},
+		}
+	}
+	oomKilled := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+		{Name: "proxy"},
+		lastTerminated("OOMKilled"),
+	}}}
+	crashed := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{lastTerminated("Error")}}}
+	initCrashed := &v1.Pod{Status: v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{lastTerminated("Error")}}}
+	// currently terminated and not restarted, e.g. a failed Job pod
+	failedJob := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+		Name:  "app",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error"}},
+	}}}}
+	match := func(reason string, pod *v1.Pod) bool {
+		return (&PodHandler{}).getMatcher(ActionOptions{RestartReason: reason})(pod)
+	}
+
+	assert.True(t, match("OOMKilled", oomKilled))
+	assert.True(t, match("oomkilled", oomKilled))
+	assert.False(t, match("OOMKilled", crashed))
+	assert.True(t, match("Error", crashed))
+	assert.True(t, match("Error", initCrashed))
+	assert.False(t, match("Error", oomKilled))
+	assert.True(t, match("Error", failedJob))
+	assert.False(t, match("Error", &v1.Pod{}))
+}
+
+func TestPodMatcher_ExitCode(t *testing.T) {
+	exited := func(exitCode int32) *v1.Pod {
+		return &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:                 "app",
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: exitCode}},
+		}}}}
+	}
+	match := func(options ActionOptions, pod *v1.Pod) bool {
+		return (&PodHandler{}).getMatcher(options)(pod)
+	}
+	code := func(exitCode int32) ActionOptions {
+		return ActionOptions{ExitCode: &exitCode}
+	}
+	nonzero := ActionOptions{ExitCodeNonzero: true}
+
+	assert.True(t, match(code(0), exited(0)))
+	assert.False(t, match(code(0), exited(1)))
+	assert.True(t, match(code(137), exited(137)))
+	assert.False(t, match(code(137), exited(1)))
+	assert.True(t, match(code(1), exited(1)))
+
+	assert.False(t, match(nonzero, exited(0)))
+	assert.True(t, match(nonzero, exited(1)))
+	assert.True(t, match(nonzero, exited(137)))
+
+	// pods of Jobs are not restarted, the termination is only recorded in the current state
+	failedJob := &v1.Pod{
+		Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyNever},
+		Status: v1.PodStatus{Phase: v1.PodFailed, ContainerStatuses: []v1.ContainerStatus{{
+			Name:  "backup",
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 2}},
+		}}},
+	}
+	assert.True(t, match(code(2), failedJob))
+	assert.False(t, match(code(1), failedJob))
+	assert.True(t, match(nonzero, failedJob))
+
+	neverTerminated := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "app"}}}}
+	assert.False(t, match(code(0), neverTerminated))
+	assert.False(t, match(nonzero, neverTerminated))
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/alikhil/kubectl-find/pkg/prompts"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// applyFieldManager is the field manager used for server-side apply of restored manifests.
+const applyFieldManager = "kubectl-find"
+
+type ApplyFromOptions struct {
+	Dir         string // directory with manifests previously saved with --save-to
+	Namespace   string // namespace for namespaced manifests that do not specify one
+	Client      dynamic.Interface
+	Mapper      meta.RESTMapper
+	SkipConfirm bool // skip confirmation prompt before applying
+	Streams     *genericclioptions.IOStreams
+}
+
+type manifest struct {
+	path string
+	obj  *unstructured.Unstructured
+}
+
+// readManifests reads all YAML manifests from the directory, sorted by file name.
+func readManifests(dir string) ([]manifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	manifests := make([]manifest, 0, len(paths))
+	for _, path := range paths {
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, readErr)
+		}
+		obj := &unstructured.Unstructured{}
+		if err = yaml.Unmarshal(data, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("manifest %s must have kind and metadata.name", path)
+		}
+		manifests = append(manifests, manifest{path: path, obj: obj})
+	}
+	return manifests, nil
+}
+
+// ApplyFromDirectory re-creates resources from manifests in a directory using server-side apply.
+// Each manifest is applied independently and the result is reported per file.
+func ApplyFromDirectory(ctx context.Context, options ApplyFromOptions) error {
+	manifests, err := readManifests(options.Dir)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		fmt.Fprintf(options.Streams.ErrOut, "No manifests found in %s\n", options.Dir)
+		return nil
+	}
+
+	if !options.SkipConfirm {
+		fmt.Fprintf(options.Streams.ErrOut, "The following manifests will be applied:\n")
+		for _, m := range manifests {
+			fmt.Fprintf(options.Streams.ErrOut, "- %s\n", filepath.Base(m.path))
+		}
+		if !prompts.AskForConfirmation(options.Streams) {
+			_, err = options.Streams.ErrOut.Write([]byte("Apply cancelled.\n"))
+			if err != nil {
+				return fmt.Errorf("failed to write to error output: %w", err)
+			}
+			return nil
+		}
+	}
+
+	failed := 0
+	for _, m := range manifests {
+		if err = applyManifest(ctx, options, m.obj); err != nil {
+			failed++
+			fmt.Fprintf(options.Streams.ErrOut, "Failed to apply %s: %v\n", filepath.Base(m.path), err)
+			continue
+		}
+		fmt.Fprintf(options.Streams.Out, "Applied %s %s from %s\n",
+			m.obj.GetKind(), m.obj.GetName(), filepath.Base(m.path))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d manifests", failed, len(manifests))
+	}
+	return nil
+}
+
+func applyManifest(ctx context.Context, options ApplyFromOptions, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	mapping, err := options.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("unable to resolve resource for %s: %w", gvk.String(), err)
+	}
+
+	var resources dynamic.ResourceInterface
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(options.Namespace)
+		}
+		resources = options.Client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	} else {
+		resources = options.Client.Resource(mapping.Resource)
+	}
+
+	_, err = resources.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: applyFieldManager})
+	return err
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApplyFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	manifests := map[string]string{
+		"default_configmap_settings.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: default\n",
+		"configmap_defaults.yaml":         "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: defaults\n",
+		"widget_unknown.yaml":             "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: unknown\n",
+		"notes.txt":                       "not a manifest",
+	}
+	for name, content := range manifests {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(v1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	var applied []string
+	client.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		assert.Equal(t, k8s_types.ApplyPatchType, patch.GetPatchType())
+		applied = append(applied, patch.GetNamespace()+"/"+patch.GetName())
+		return true, nil, nil
+	})
+
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	err := ApplyFromDirectory(t.Context(), ApplyFromOptions{
+		Dir:         dir,
+		Namespace:   "restore",
+		Client:      client,
+		Mapper:      mapper,
+		SkipConfirm: true,
+		Streams:     &streams,
+	})
+	require.EqualError(t, err, "failed to apply 1 of 3 manifests")
+
+	assert.Equal(t, []string{"restore/defaults", "default/settings"}, applied)
+	assert.Equal(t, "Applied ConfigMap defaults from configmap_defaults.yaml\n"+
+		"Applied ConfigMap settings from default_configmap_settings.yaml\n", out.String())
+	assert.Contains(t, errOut.String(), "Failed to apply widget_unknown.yaml: unable to resolve resource")
+}
+
+func TestApplyFromDirectory_Cancelled(t *testing.T) {
+	dir := t.TempDir()
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: default\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default_configmap_settings.yaml"), []byte(content), 0o600))
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+	in.WriteString("n\n")
+
+	err := ApplyFromDirectory(t.Context(), ApplyFromOptions{
+		Dir:     dir,
+		Client:  client,
+		Mapper:  meta.NewDefaultRESTMapper(nil),
+		Streams: &streams,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, client.Actions())
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "- default_configmap_settings.yaml\n")
+	assert.Contains(t, errOut.String(), "Apply cancelled.\n")
+}
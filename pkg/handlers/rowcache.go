@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// rowCache converts the object of the row being printed to its typed form once and shares it
+// across all columns of that row. Printers evaluate every column of a row before moving to the next one,
+// so remembering only the last object is enough. A rowCache must not be shared between goroutines.
+type rowCache[T any] struct {
+	object map[string]interface{}
+	typed  *T
+	err    error
+}
+
+func newRowCache[T any]() *rowCache[T] {
+	return &rowCache[T]{}
+}
+
+// get returns the typed form of obj, converting it only when obj differs from the previous one.
+func (c *rowCache[T]) get(obj unstructured.Unstructured) (*T, error) {
+	if c.object == nil || reflect.ValueOf(c.object).UnsafePointer() != reflect.ValueOf(obj.Object).UnsafePointer() {
+		c.object = obj.Object
+		c.typed = new(T)
+		c.err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, c.typed)
+	}
+	return c.typed, c.err
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_rowCache(t *testing.T) {
+	first := toUnstructured(t, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	second := toUnstructured(t, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}})
+	cache := newRowCache[v1.Node]()
+
+	node, err := cache.get(first)
+	require.NoError(t, err)
+	require.Equal(t, "node-1", node.Name)
+
+	again, err := cache.get(first)
+	require.NoError(t, err)
+	require.Same(t, node, again, "the same row must not be converted twice")
+
+	node, err = cache.get(second)
+	require.NoError(t, err)
+	require.Equal(t, "node-2", node.Name)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const tableAcceptHeader = "application/json;as=Table;g=meta.k8s.io;v=v1"
+
+// serverTablePageLimit is the page size of server tables, unless --list-options sets a limit.
+const serverTablePageLimit = 500
+
+// tableListOptions returns the options to request a page of the server table with.
+// The resource version only applies to the first page, the API server rejects it with a continue token.
+func tableListOptions(listOptions metav1.ListOptions, continueToken string) metav1.ListOptions {
+	if listOptions.Limit == 0 {
+		listOptions.Limit = serverTablePageLimit
+	}
+	if continueToken != "" {
+		listOptions.Continue = continueToken
+		listOptions.ResourceVersion = ""
+		listOptions.ResourceVersionMatch = ""
+	}
+	return listOptions
+}
+
+// getResourcePath builds the API path used to list the resource in the given namespace.
+func getResourcePath(resource Resource, namespace string) string {
+	prefix := path.Join("/apis", resource.GroupVersionResource.Group, resource.GroupVersionResource.Version)
+	if resource.GroupVersionResource.Group == "" {
+		prefix = path.Join("/api", resource.GroupVersionResource.Version)
+	}
+	if resource.IsNamespaced && namespace != "" {
+		prefix = path.Join(prefix, "namespaces", namespace)
+	}
+	return path.Join(prefix, resource.GroupVersionResource.Resource)
+}
+
+// newServerTableFetcher returns a TableFetcher that requests the Table representation of the resource
+// so that the server-defined columns (including CRD printer columns) can be printed.
+// Tables are requested with the options the printed objects were listed with,
+// instead of for every object in the namespace.
+func newServerTableFetcher(
+	client rest.Interface,
+	resource Resource,
+	listOptions metav1.ListOptions,
+) printers.TableFetcher {
+	return func(ctx context.Context, namespace, continueToken string) (*metav1.Table, error) {
+		pageOptions := tableListOptions(listOptions, continueToken)
+		raw, err := client.Get().
+			AbsPath(getResourcePath(resource, namespace)).
+			SetHeader("Accept", tableAcceptHeader).
+			SpecificallyVersionedParams(&pageOptions, metav1.ParameterCodec, metav1.SchemeGroupVersion).
+			Param("includeObject", string(metav1.IncludeMetadata)).
+			Do(ctx).
+			Raw()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table for %s: %w", resource.PluralName, err)
+		}
+		table := &metav1.Table{}
+		if err = json.Unmarshal(raw, table); err != nil {
+			return nil, fmt.Errorf("failed to decode table for %s: %w", resource.PluralName, err)
+		}
+		return table, nil
+	}
+}
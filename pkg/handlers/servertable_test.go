@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+func TestServerTableFetcher_ListOptions(t *testing.T) {
+	var requests []*http.Request
+	client := &restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"kind":"Table","apiVersion":"meta.k8s.io/v1"}`)),
+			}, nil
+		}),
+	}
+	timeout := int64(30)
+	fetch := newServerTableFetcher(client, configMapType, ListOptionsWithSelectors(
+		metav1.ListOptions{ResourceVersion: "0", TimeoutSeconds: &timeout},
+		"app=web",
+		"metadata.namespace!=kube-system",
+	))
+
+	_, err := fetch(t.Context(), "default", "")
+	require.NoError(t, err)
+	_, err = fetch(t.Context(), "default", "next-page")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/api/v1/namespaces/default/configmaps", requests[0].URL.Path)
+	assert.Equal(t, tableAcceptHeader, requests[0].Header.Get("Accept"))
+	first := requests[0].URL.Query()
+	assert.Equal(t, "app=web", first.Get("labelSelector"))
+	assert.Equal(t, "metadata.namespace!=kube-system", first.Get("fieldSelector"))
+	assert.Equal(t, "500", first.Get("limit"))
+	assert.Equal(t, "0", first.Get("resourceVersion"))
+	assert.Equal(t, "30", first.Get("timeoutSeconds"))
+	assert.Equal(t, "Metadata", first.Get("includeObject"))
+
+	next := requests[1].URL.Query()
+	assert.Equal(t, "next-page", next.Get("continue"))
+	assert.Empty(t, next.Get("resourceVersion"), "the resource version only applies to the first page")
+}
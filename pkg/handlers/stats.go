@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MatchStats counts how many objects were fetched from the API server and how many of them matched the filters.
+type MatchStats struct {
+	mu      sync.Mutex
+	fetched int
+	matched int
+}
+
+// NewMatchStats creates an empty stats accumulator.
+func NewMatchStats() *MatchStats {
+	return &MatchStats{}
+}
+
+// Add records a listing of fetched objects of which matched passed the filters.
+// Counts from several listings, e.g. in several contexts, are summed up.
+func (s *MatchStats) Add(fetched, matched int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetched += fetched
+	s.matched += matched
+}
+
+// Print writes the summary line, e.g. "fetched 12000, matched 37".
+func (s *MatchStats) Print(out io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintf(out, "fetched %d, matched %d\n", s.fetched, s.matched)
+	return err
+}
+
+// NamespaceCounts tallies matched objects by namespace for --count-by-namespace.
+type NamespaceCounts struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewNamespaceCounts creates an empty namespace tally.
+func NewNamespaceCounts() *NamespaceCounts {
+	return &NamespaceCounts{counts: map[string]int{}}
+}
+
+// Add counts the matched objects by their namespaces.
+// Counts from several listings, e.g. in several contexts, are summed up.
+func (c *NamespaceCounts) Add(objects ...metav1.Object) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, obj := range objects {
+		c.counts[obj.GetNamespace()]++
+	}
+}
+
+// Print writes the breakdown sorted by namespace, e.g. "default: 12, kube-system: 30".
+func (c *NamespaceCounts) Print(out io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.counts) == 0 {
+		_, err := fmt.Fprintln(out, "no matches in any namespace")
+		return err
+	}
+	namespaces := make([]string, 0, len(c.counts))
+	for namespace := range c.counts {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	parts := make([]string, len(namespaces))
+	for i, namespace := range namespaces {
+		parts[i] = fmt.Sprintf("%s: %d", namespace, c.counts[namespace])
+	}
+	_, err := fmt.Fprintln(out, strings.Join(parts, ", "))
+	return err
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMatchStats(t *testing.T) {
+	stats := NewMatchStats()
+	// listings in several contexts are summed up
+	stats.Add(12000, 30)
+	stats.Add(500, 7)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, stats.Print(out))
+	assert.Equal(t, "fetched 12500, matched 37\n", out.String())
+
+	var nilStats *MatchStats
+	assert.NotPanics(t, func() {
+		nilStats.Add(1, 1)
+	})
+}
+
+func TestHandlers_Stats(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+
+	tests := map[string]ResourceHandler{
+		"pods": &PodHandler{clientSet: fake.NewClientset(
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default"}},
+		)},
+		"configmaps": &UniversalHandler{opts: UniversalHandlerOptions{
+			Client: dynamicfake.NewSimpleDynamicClient(scheme,
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: "default"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-env", Namespace: "default"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "api-config", Namespace: "default"}},
+			),
+			Resource: configMapType,
+		}},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			stats := NewMatchStats()
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:    "default",
+				Action:       ActionDelete,
+				NameRegex:    regexp.MustCompile("^api"),
+				SkipConfirm:  true,
+				ResourceType: configMapType,
+				Streams:      &streams,
+				Stats:        stats,
+			})
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			require.NoError(t, stats.Print(out))
+			assert.Equal(t, "fetched 3, matched 1\n", out.String())
+		})
+	}
+}
+
+func TestNamespaceCounts(t *testing.T) {
+	counts := NewNamespaceCounts()
+	out := &bytes.Buffer{}
+	require.NoError(t, counts.Print(out))
+	assert.Equal(t, "no matches in any namespace\n", out.String())
+
+	inNamespace := func(namespace string) metav1.Object {
+		return &metav1.ObjectMeta{Name: "web", Namespace: namespace}
+	}
+	counts.Add(inNamespace("kube-system"), inNamespace("default"), inNamespace("kube-system"))
+	// listings in several contexts are summed up
+	counts.Add(inNamespace("monitoring"), inNamespace("default"), inNamespace("kube-system"))
+
+	out.Reset()
+	require.NoError(t, counts.Print(out))
+	assert.Equal(t, "default: 2, kube-system: 3, monitoring: 1\n", out.String())
+
+	var nilCounts *NamespaceCounts
+	assert.NotPanics(t, func() {
+		nilCounts.Add(inNamespace("default"))
+	})
+}
+
+func TestHandlers_CountByNamespace(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+
+	tests := map[string]ResourceHandler{
+		"pods": &PodHandler{clientSet: fake.NewClientset(
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "staging"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "staging"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "production"}},
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "production"}},
+		), nameOutput: true},
+		"configmaps": &UniversalHandler{opts: UniversalHandlerOptions{
+			Client: dynamicfake.NewSimpleDynamicClient(scheme,
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "staging"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "staging"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "production"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "production"}},
+			),
+			Resource: configMapType,
+			Printer:  printers.NewNamePrinter(printers.NamePrinterOptions{}),
+		}},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			counts := NewNamespaceCounts()
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Action:       ActionList,
+				NameRegex:    regexp.MustCompile("^web"),
+				ResourceType: configMapType,
+				Streams:      &streams,
+				ByNamespace:  counts,
+			})
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			require.NoError(t, counts.Print(out))
+			assert.Equal(t, "production: 1, staging: 2\n", out.String())
+		})
+	}
+}
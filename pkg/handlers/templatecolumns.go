@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// templateFunctionRegex matches a function call in a template column expression, e.g. age(.metadata.creationTimestamp).
+var templateFunctionRegex = regexp.MustCompile(`^([a-z]+)\((.*)\)$`)
+
+// templateValue evaluates a template column expression against an object.
+type templateValue func(obj unstructured.Unstructured) string
+
+// ParseTemplateColumns parses a template columns spec: HEADER=EXPR[,HEADER2=EXPR2].
+// An expression is a JSONPath or one of the functions:
+//   - age(EXPR) prints a timestamp as age, like the AGE column;
+//   - json(JSONPATH) prints the value as compact JSON, e.g. to show a whole map or list;
+//   - default(EXPR, TEXT) prints TEXT when the value is missing or empty.
+//
+// Functions can be nested, e.g. default(age(.status.startTime), never).
+func ParseTemplateColumns(spec string) ([]printers.Column, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, errors.New("template columns spec cannot be empty")
+	}
+
+	var columns []printers.Column
+	for _, part := range splitTopLevel(spec) {
+		header, expr, found := strings.Cut(strings.TrimSpace(part), "=")
+		header, expr = strings.TrimSpace(header), strings.TrimSpace(expr)
+		if !found || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid template column %q: expected HEADER=EXPR", part)
+		}
+
+		value, err := parseTemplateExpr(header, expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression %q for column %q: %w", expr, header, err)
+		}
+		columns = append(columns, printers.Column{Header: strings.ToUpper(header), Value: value})
+	}
+	return columns, nil
+}
+
+func parseTemplateExpr(header, expr string) (templateValue, error) {
+	match := templateFunctionRegex.FindStringSubmatch(expr)
+	if match == nil {
+		jp, err := parseColumnJSONPath(header, expr)
+		if err != nil {
+			return nil, err
+		}
+		return func(obj unstructured.Unstructured) string {
+			return extractValueFromJSONPath(obj, jp)
+		}, nil
+	}
+
+	function, args := match[1], splitTopLevel(match[2])
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	switch function {
+	case "age":
+		if len(args) != 1 || args[0] == "" {
+			return nil, errors.New("age() expects a single argument")
+		}
+		inner, err := parseTemplateExpr(header, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(obj unstructured.Unstructured) string {
+			return formatAge(inner(obj))
+		}, nil
+	case "json":
+		if len(args) != 1 || args[0] == "" {
+			return nil, errors.New("json() expects a single JSONPath argument")
+		}
+		jp, err := parseColumnJSONPath(header, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(obj unstructured.Unstructured) string {
+			results, findErr := jp.FindResults(obj.UnstructuredContent())
+			if findErr != nil || len(results) == 0 || len(results[0]) == 0 {
+				return NoneStr
+			}
+			data, marshalErr := json.Marshal(results[0][0].Interface())
+			if marshalErr != nil {
+				return NoneStr
+			}
+			return string(data)
+		}, nil
+	case "default":
+		if len(args) != 2 || args[0] == "" {
+			return nil, errors.New("default() expects an expression and a fallback text")
+		}
+		inner, err := parseTemplateExpr(header, args[0])
+		if err != nil {
+			return nil, err
+		}
+		fallback := args[1]
+		return func(obj unstructured.Unstructured) string {
+			if value := inner(obj); value != NoneStr && value != "" {
+				return value
+			}
+			return fallback
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown function %q, must be one of: age, default, json", function)
+	}
+}
+
+// splitTopLevel splits s by commas that are not nested in parentheses, brackets or braces.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseTemplateColumns(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "web",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-3 * time.Hour)),
+		},
+		Spec: v1.PodSpec{NodeSelector: map[string]string{"disktype": "ssd"}},
+	}
+	obj := toUnstructured(t, pod)
+
+	tests := []struct {
+		name       string
+		spec       string
+		wantHeader string
+		want       string
+	}{
+		{name: "jsonpath", spec: "name=.metadata.name", wantHeader: "NAME", want: "web"},
+		{name: "jsonpath in braces", spec: "NAME={.metadata.name}", wantHeader: "NAME", want: "web"},
+		{name: "missing value", spec: "NODE=.spec.nodeName", wantHeader: "NODE", want: NoneStr},
+		{name: "age", spec: "AGE=age(.metadata.creationTimestamp)", wantHeader: "AGE", want: "3h"},
+		{name: "age of missing value", spec: "STARTED=age(.status.startTime)", wantHeader: "STARTED", want: NoneStr},
+		{name: "json map", spec: "SELECTOR=json(.spec.nodeSelector)", wantHeader: "SELECTOR", want: `{"disktype":"ssd"}`},
+		{name: "json string", spec: "NAME=json(.metadata.name)", wantHeader: "NAME", want: `"web"`},
+		{name: "json of missing value", spec: "TOLERATIONS=json(.spec.tolerations)", wantHeader: "TOLERATIONS", want: NoneStr},
+		{name: "default for missing value", spec: "NODE=default(.spec.nodeName, unscheduled)", wantHeader: "NODE", want: "unscheduled"},
+		{name: "default for present value", spec: "NAME=default(.metadata.name,unnamed)", wantHeader: "NAME", want: "web"},
+		{name: "nested functions", spec: "STARTED=default(age(.status.startTime), never)", wantHeader: "STARTED", want: "never"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := ParseTemplateColumns(tt.spec)
+			require.NoError(t, err)
+			require.Len(t, columns, 1)
+			assert.Equal(t, tt.wantHeader, columns[0].Header)
+			assert.Equal(t, tt.want, columns[0].Value(obj))
+		})
+	}
+}
+
+func TestParseTemplateColumns_Several(t *testing.T) {
+	columns, err := ParseTemplateColumns("NAME=.metadata.name, NODE=default(.spec.nodeName, pending),SELECTOR=json(.spec.nodeSelector)")
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+	assert.Equal(t, []string{"NAME", "NODE", "SELECTOR"},
+		[]string{columns[0].Header, columns[1].Header, columns[2].Header})
+}
+
+func TestParseTemplateColumns_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{name: "empty spec", spec: " ", wantErr: "template columns spec cannot be empty"},
+		{name: "missing expression", spec: "NAME=.metadata.name,NODE", wantErr: `invalid template column "NODE": expected HEADER=EXPR`},
+		{
+			name:    "unknown function",
+			spec:    "AGE=since(.metadata.creationTimestamp)",
+			wantErr: `invalid expression "since(.metadata.creationTimestamp)" for column "AGE": unknown function "since"`,
+		},
+		{name: "age without argument", spec: "AGE=age()", wantErr: "age() expects a single argument"},
+		{name: "default without fallback", spec: "NODE=default(.spec.nodeName)", wantErr: "default() expects an expression and a fallback text"},
+		{name: "malformed jsonpath", spec: "NODE=age(.spec.containers[)", wantErr: `invalid expression "age(.spec.containers[)" for column "NODE"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplateColumns(tt.spec)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/alikhil/kubectl-find/pkg"
@@ -17,6 +18,8 @@ import (
 	k8s_types "k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
 )
 
 // ResourceMatcher is a function that determines whether a resource matches
@@ -34,6 +37,7 @@ type UniversalHandlerOptions struct {
 	Printer         printers.BatchPrinter
 	Resource        Resource
 	ResourceMatcher ResourceMatcher // optional resource-specific matcher injected during handler creation
+	WorkloadPods    *workloadPods   // prints pods of matched workloads instead of the workloads when set
 }
 
 func NewUniversalHandler(opts UniversalHandlerOptions) *UniversalHandler {
@@ -63,29 +67,61 @@ func (h *UniversalHandler) printResource(
 	return nil
 }
 
+// printPatchDiff prints the changes the patch would make to the resource.
+func (h *UniversalHandler) printPatchDiff(
+	out io.Writer,
+	resource unstructured.Unstructured,
+	patch []byte,
+	options ActionOptions,
+) error {
+	original, err := resource.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", h.opts.Resource.SingularName, resource.GetName(), err)
+	}
+
+	return printPatchDiff(
+		out,
+		h.opts.Resource.SingularName+"/"+resource.GetName(),
+		original,
+		patch,
+		options.PatchStrategy,
+		h.patchDataStruct(),
+	)
+}
+
+// patchDataStruct returns the typed object used to look up strategic merge keys of the resource,
+// or nil for custom resources, which fall back to merge patch.
+func (h *UniversalHandler) patchDataStruct() interface{} {
+	if typed, err := scheme.Scheme.New(h.opts.Resource.GroupVersionKind); err == nil {
+		return typed
+	}
+	return nil
+}
+
+// getResources lists resources page by page; the returned resource version of the list can be used to watch changes.
 func (h *UniversalHandler) getResources(
 	ctx context.Context,
 	resources dynamic.ResourceInterface,
 	options ActionOptions,
-) ([]unstructured.Unstructured, error) {
+) ([]unstructured.Unstructured, string, error) {
 	var allResources []unstructured.Unstructured
 	continueToken := ""
+	pages := 0
 	for {
-		listOptions := v1.ListOptions{
-			LabelSelector: options.LabelSelector,
-			Continue:      continueToken,
-		}
-		list, err := resources.List(ctx, listOptions)
+		list, err := resources.List(ctx, pageListOptions(options, options.FieldSelector, continueToken))
 		if err != nil {
-			return nil, fmt.Errorf("failed to list resources: %w", err)
+			return nil, "", fmt.Errorf("failed to list resources: %w", err)
 		}
 		allResources = append(allResources, list.Items...)
+		pages++
+		klog.V(3).InfoS("Listed page of "+h.opts.Resource.PluralName, "namespace", options.Namespace, "page", pages,
+			"items", len(list.Items), "total", len(allResources))
+		options.reportProgress(ProgressEvent{Phase: ProgressPhaseListing, PagesDone: pages, Listed: len(allResources)})
 		continueToken = list.GetContinue()
 		if continueToken == "" {
-			break
+			return allResources, list.GetResourceVersion(), nil
 		}
 	}
-	return allResources, nil
 }
 
 func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptions) error {
@@ -99,27 +135,69 @@ func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptio
 		resources = h.opts.Client.Resource(h.opts.Resource.GroupVersionResource)
 	}
 
-	list, err := h.getResources(ctx, resources, options)
+	stopListing := options.Profiler.Start(ProgressPhaseListing)
+	list, resourceVersion, err := h.getResources(ctx, resources, options)
+	if searchesForbiddenNamespaces(options, h.opts.Resource.IsNamespaced, err) {
+		list, err = listAccessibleNamespaces(ctx, options, h.namespaceNames, h.listInNamespace, err)
+		list = uniqueByUID(list, unstructuredUID)
+	}
+	stopListing(len(list))
 	if err != nil {
 		return fmt.Errorf("failed to list %s: %w", h.opts.Resource.PluralName, err)
 	}
+	if needsLabelHint(options, len(list)) {
+		h.hintLabelKeys(ctx, options)
+	}
 
 	matchedItems := make([]unstructured.Unstructured, 0, len(list))
+	healthChecked := 0
 	for _, item := range list {
-		if h.resourceMatches(item, &options) {
+		matched := h.filtersMatch(item, &options)
+		if matched && options.Health {
+			healthChecked++
+			matched = isUnhealthyWorkload(item, h.opts.Resource.GroupVersionResource)
+		}
+		logMatch(h.opts.Resource.SingularName, item.GetNamespace(), item.GetName(), matched)
+		if matched {
 			matchedItems = append(matchedItems, item)
 		}
 	}
+	if options.ZeroReplicas && h.opts.Resource.GroupVersionResource == ReplicaSetType {
+		if matchedItems, err = h.withoutCurrentReplicaSets(ctx, matchedItems); err != nil {
+			return err
+		}
+	}
+	options.Stats.Add(len(list), len(matchedItems))
+	for i := range matchedItems {
+		options.auditMatched(&matchedItems[i])
+		options.ByNamespace.Add(&matchedItems[i])
+	}
+	sortMatched(options, sortby.UnstructuredSlice(matchedItems), map[string]sort.Interface{
+		SortByAge: sortby.UnstructuredByAge(matchedItems),
+	})
+
+	if options.Watch {
+		return h.watch(ctx, resources, options, matchedItems, resourceVersion)
+	}
+	if options.Health && options.Action == ActionList {
+		defer printHealthSummary(options.Streams.ErrOut, len(matchedItems), healthChecked, h.opts.Resource.PluralName)
+	}
 	if len(matchedItems) == 0 {
 		return nil
 	}
 
-	if options.NaturalSort {
-		sort.Sort(sortby.UnstructuredSlice(matchedItems))
+	if options.SaveTo != "" {
+		if err = saveObjects(options.SaveTo, matchedItems, h.opts.Resource.GroupVersionKind); err != nil {
+			return fmt.Errorf("failed to save %s: %w", h.opts.Resource.PluralName, err)
+		}
+		fmt.Fprintf(options.Streams.ErrOut, "Saved %d %s to %s\n", len(matchedItems), h.opts.Resource.PluralName, options.SaveTo)
 	}
 
 	if options.Action == ActionList {
-		return h.opts.Printer.PrintObjects(matchedItems, options.Streams.Out)
+		if h.opts.WorkloadPods != nil {
+			return h.opts.WorkloadPods.print(ctx, matchedItems, options.Streams.Out)
+		}
+		return h.opts.Printer.PrintObjects(ctx, matchedItems, options.Streams.Out)
 	}
 
 	if options.Action == ActionDelete {
@@ -139,30 +217,60 @@ func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptio
 				return nil
 			}
 		}
-		for _, item := range matchedItems {
+		defer options.Profiler.Start(ProgressPhaseDeleting)(len(matchedItems))
+		for i, item := range matchedItems {
 			deletionPropagation := v1.DeletePropagationBackground
 			deleteOptions := v1.DeleteOptions{PropagationPolicy: &deletionPropagation}
 			if options.Force {
 				gracePeriod := int64(0)
 				deleteOptions.GracePeriodSeconds = &gracePeriod
 			}
-			if err = resources.Delete(ctx, item.GetName(), deleteOptions); err != nil {
+			err = h.itemResources(resources, item).Delete(ctx, item.GetName(), deleteOptions)
+			options.auditDone(&item, err)
+			if err != nil {
 				return fmt.Errorf("failed to delete %s %s: %w", h.opts.Resource.SingularName, item.GetName(), err)
 			}
 			fmt.Fprintf(options.Streams.Out, "Deleted %s %s\n", h.opts.Resource.SingularName, item.GetName())
+			options.reportProgress(ProgressEvent{Phase: ProgressPhaseDeleting, Done: i + 1, Total: len(matchedItems)})
 		}
 		return nil
 	}
 	if options.Action == ActionPatch {
-		if options.Patch == "" {
+		if options.Patch == "" && options.PatchTemplate == nil {
 			return errors.New("patch content is required for patch action")
 		}
+		patches := make([][]byte, len(matchedItems))
+		for i, item := range matchedItems {
+			if patches[i], err = renderPatch(options, item.Object); err != nil {
+				return fmt.Errorf("%s %s: %w", h.opts.Resource.SingularName, item.GetName(), err)
+			}
+			if err = checkJSONPatch(options, patches[i], item.Object); err != nil {
+				return fmt.Errorf("%s %s: %w", h.opts.Resource.SingularName, item.GetName(), err)
+			}
+		}
+		if options.Diff {
+			for i, item := range matchedItems {
+				if err = h.printPatchDiff(options.Streams.Out, item, patches[i], options); err != nil {
+					return err
+				}
+			}
+		}
 		if !options.SkipConfirm {
-			fmt.Fprintf(options.Streams.ErrOut, "The following %s will be patched:\n", h.opts.Resource.PluralName)
-			for _, res := range matchedItems {
-				err = h.printResource(res, options, options.Streams.ErrOut)
+			if !options.Diff {
+				fmt.Fprintf(options.Streams.ErrOut, "The following %s will be patched:\n", h.opts.Resource.PluralName)
+				for _, res := range matchedItems {
+					err = h.printResource(res, options, options.Streams.ErrOut)
+					if err != nil {
+						return fmt.Errorf("failed to write to error output: %w", err)
+					}
+				}
+			}
+			if options.PreviewDiff {
+				err = printPreviewDiffs(options.Streams.ErrOut, len(matchedItems), func(i int, out io.Writer) error {
+					return h.printPatchDiff(out, matchedItems[i], patches[i], options)
+				})
 				if err != nil {
-					return fmt.Errorf("failed to write to error output: %w", err)
+					return err
 				}
 			}
 			if !prompts.AskForConfirmation(options.Streams) {
@@ -173,17 +281,54 @@ func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptio
 				return nil
 			}
 		}
-		for _, item := range matchedItems {
-			patchBytes := []byte(options.Patch)
-			_, err = resources.Patch(ctx, item.GetName(), options.PatchStrategy, patchBytes, v1.PatchOptions{})
+		conflicted, changed := 0, 0
+		defer options.Profiler.Start(ProgressPhasePatching)(len(matchedItems))
+		for i, item := range matchedItems {
+			patchBytes, bodyErr := patchBody(options, patches[i], &item, h.opts.Resource.GroupVersionKind)
+			if bodyErr != nil {
+				return bodyErr
+			}
+			patched, err := h.itemResources(resources, item).
+				Patch(ctx, item.GetName(), options.PatchStrategy, patchBytes, patchOptions(options))
+			options.auditDone(&item, err)
+			if conflicts, isConflict := applyConflicts(options, err); isConflict {
+				conflicted++
+				printApplyConflicts(options.Streams.ErrOut, h.opts.Resource.SingularName+" "+item.GetName(), conflicts)
+				continue
+			}
 			if err != nil {
 				return fmt.Errorf("failed to patch %s %s: %w", h.opts.Resource.SingularName, item.GetName(), err)
 			}
-			fmt.Fprintf(options.Streams.Out, "Patched %s %s\n", h.opts.Resource.SingularName, item.GetName())
+			itemChanged := true
+			if patched != nil {
+				itemChanged, err = patchChanged(
+					options.PatchStrategy, patchBytes, item.Object, patched.Object, h.patchDataStruct())
+				if err != nil {
+					return fmt.Errorf("failed to check changes of %s %s: %w",
+						h.opts.Resource.SingularName, item.GetName(), err)
+				}
+			}
+			if itemChanged {
+				changed++
+			}
+			fmt.Fprintf(options.Streams.Out, "Patched %s %s%s\n",
+				h.opts.Resource.SingularName, item.GetName(), patchedSuffix(itemChanged))
+			options.reportProgress(ProgressEvent{Phase: ProgressPhasePatching, Done: i + 1, Total: len(matchedItems)})
+		}
+		err = printPatchSummary(options.Streams.Out, changed, len(matchedItems)-conflicted, h.opts.Resource.PluralName)
+		if err != nil {
+			return fmt.Errorf("failed to write to output: %w", err)
+		}
+		if conflicted > 0 {
+			return applyConflictsError(conflicted, len(matchedItems), h.opts.Resource.PluralName)
 		}
 		return nil
 	}
 
+	if options.Action == ActionExecEach {
+		return execEach(ctx, options, matchedItems, h.opts.Resource.PluralName)
+	}
+
 	if options.Action == ActionAnnotate {
 		if options.Annotate.IsEmpty() {
 			return errors.New("annotation changes are required for annotate action")
@@ -208,12 +353,15 @@ func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptio
 				return nil
 			}
 		}
-		for _, item := range matchedItems {
-			_, err = resources.Patch(ctx, item.GetName(), k8s_types.MergePatchType, patchBytes, v1.PatchOptions{})
+		defer options.Profiler.Start(ProgressPhaseAnnotating)(len(matchedItems))
+		for i, item := range matchedItems {
+			_, err = h.itemResources(resources, item).Patch(ctx, item.GetName(), k8s_types.MergePatchType, patchBytes, v1.PatchOptions{})
+			options.auditDone(&item, err)
 			if err != nil {
 				return fmt.Errorf("failed to annotate %s %s: %w", h.opts.Resource.SingularName, item.GetName(), err)
 			}
 			fmt.Fprintf(options.Streams.Out, "Annotated %s %s\n", h.opts.Resource.SingularName, item.GetName())
+			options.reportProgress(ProgressEvent{Phase: ProgressPhaseAnnotating, Done: i + 1, Total: len(matchedItems)})
 		}
 		return nil
 	}
@@ -222,9 +370,21 @@ func (h *UniversalHandler) HandleAction(ctx context.Context, options ActionOptio
 }
 
 func (h *UniversalHandler) resourceMatches(resource unstructured.Unstructured, options *ActionOptions) bool {
+	if !h.filtersMatch(resource, options) {
+		return false
+	}
+	return !options.Health || isUnhealthyWorkload(resource, h.opts.Resource.GroupVersionResource)
+}
+
+// filtersMatch returns true if the resource passes every filter except --health,
+// which is checked separately so that the health summary counts only the workloads it was applied to.
+func (h *UniversalHandler) filtersMatch(resource unstructured.Unstructured, options *ActionOptions) bool {
 	if options.NameRegex != nil && !options.NameRegex.MatchString(resource.GetName()) {
 		return false
 	}
+	if nameExcluded(resource.GetName(), options.NameExclude) {
+		return false
+	}
 
 	if options.MinAge > 0 || options.MaxAge > 0 {
 		creationTime := resource.GetCreationTimestamp()
@@ -236,12 +396,42 @@ func (h *UniversalHandler) resourceMatches(resource unstructured.Unstructured, o
 		}
 	}
 
+	if !createdBetween(resource.GetCreationTimestamp().Time, *options) {
+		return false
+	}
+
+	if options.Stale && !isStale(resource) {
+		return false
+	}
+
+	if options.Phase != "" {
+		phase, _, _ := unstructured.NestedString(resource.Object, "status", "phase")
+		if !strings.EqualFold(phase, options.Phase) {
+			return false
+		}
+	}
+
+	if options.ZeroReplicas && !hasZeroReplicas(resource) {
+		return false
+	}
+
+	if len(options.LabelCompare) > 0 && !labelComparisonsMatch(resource.GetLabels(), options.LabelCompare) {
+		return false
+	}
+
+	if len(options.Conditions) > 0 && !conditionsMatch(resourceConditions(resource), options.Conditions) {
+		return false
+	}
+
+	if len(options.ConditionAges) > 0 && !conditionAgesMatch(resourceConditions(resource), options.ConditionAges) {
+		return false
+	}
+
 	if options.JQQuery != nil {
 		matches, err := pkg.MatchesWithGoJQ(resource.Object, options.JQQuery)
 		if err != nil || !matches {
 			return false
 		}
-		return true
 	}
 
 	if h.opts.ResourceMatcher != nil {
@@ -252,3 +442,22 @@ func (h *UniversalHandler) resourceMatches(resource unstructured.Unstructured, o
 
 	return true
 }
+
+// isStale returns true if the controller has not yet observed the latest generation of the resource.
+// Resources without status.observedGeneration are never considered stale.
+func isStale(resource unstructured.Unstructured) bool {
+	observedGeneration, found, err := unstructured.NestedInt64(resource.Object, "status", "observedGeneration")
+	if err != nil || !found {
+		return false
+	}
+	return resource.GetGeneration() != observedGeneration
+}
+
+// itemResources returns the interface that targets the item's own namespace.
+// With all namespaces selected, resources is not scoped to any namespace, so actions on it would miss the item.
+func (h *UniversalHandler) itemResources(resources dynamic.ResourceInterface, item unstructured.Unstructured) dynamic.ResourceInterface {
+	if !h.opts.Resource.IsNamespaced || item.GetNamespace() == "" {
+		return resources
+	}
+	return h.opts.Client.Resource(h.opts.Resource.GroupVersionResource).Namespace(item.GetNamespace())
+}
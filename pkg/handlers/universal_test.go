@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	k8s_types "k8s.io/apimachinery/pkg/types"
 
@@ -22,6 +23,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/dynamic"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestUniversalHandler(t *testing.T) {
@@ -45,6 +47,8 @@ func TestUniversalHandler(t *testing.T) {
 		err   error
 	}
 
+	oneReplica, twoReplicas, threeReplicas := int32(1), int32(2), int32(3)
+
 	tests := []struct {
 		name    string
 		prepare func(*testing.T, *fields, *shared) error
@@ -59,7 +63,10 @@ func TestUniversalHandler(t *testing.T) {
 
 				// Create a list of unstructured objects for the expected objects
 				expectedItems := toUL(t, s.resources...)
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(expectedItems), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(expectedItems), gomock.Any()).
+					Return(nil).
+					Times(1)
 
 				f.printer = m
 				return nil
@@ -91,7 +98,10 @@ func TestUniversalHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				expectedItems := toUL(t, s.resources...)
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(expectedItems), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(expectedItems), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -131,7 +141,7 @@ func TestUniversalHandler(t *testing.T) {
 			},
 			prepare: func(t *testing.T, f *fields, _ *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 
 				f.printer = m
 				return nil
@@ -141,7 +151,7 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List in empty namespace",
 			prepare: func(t *testing.T, f *fields, _ *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 				f.printer = m
 				return nil
 			},
@@ -171,7 +181,10 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List in all namespaces",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources...)), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -211,7 +224,10 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List resources matching regex",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -248,11 +264,58 @@ func TestUniversalHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "List resources excluding names matching several patterns",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("configmap"),
+					NameRegex:    regexp.MustCompile("^app-"),
+					NameExclude: []*regexp.Regexp{
+						regexp.MustCompile("-canary$"),
+						regexp.MustCompile("-old-"),
+					},
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "default"},
+					},
+					&v1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "app-config-canary", Namespace: "default"},
+					},
+					&v1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "app-old-config", Namespace: "default"},
+					},
+					&v1.ConfigMap{
+						TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "default"},
+					},
+				},
+			},
+		},
 		{
 			name: "List resources with min age",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -295,7 +358,10 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List resources with max age",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -536,7 +602,10 @@ func TestUniversalHandler(t *testing.T) {
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
 				expectedItems := toUL(t, s.resources...)
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(expectedItems), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(expectedItems), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -569,11 +638,146 @@ func TestUniversalHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "List terminating namespaces",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Action:       ActionList,
+					ResourceType: getResource("namespace"),
+					Phase:        "terminating",
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Namespace{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "Namespace",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "default",
+						},
+						Status: v1.NamespaceStatus{Phase: v1.NamespaceActive},
+					},
+					&v1.Namespace{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "Namespace",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "stuck",
+						},
+						Status: v1.NamespaceStatus{Phase: v1.NamespaceTerminating},
+					},
+				},
+			},
+		},
+		{
+			name: "List bound persistent volume claims",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("persistentvolumeclaim"),
+					Phase:        "Bound",
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "PersistentVolumeClaim",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "data",
+							Namespace: "default",
+						},
+						Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+					},
+					&v1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "PersistentVolumeClaim",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cache",
+							Namespace: "default",
+						},
+						Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+					},
+				},
+			},
+		},
+		{
+			name: "List pending persistent volume claims",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("persistentvolumeclaim"),
+					Phase:        "Pending",
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "PersistentVolumeClaim",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "data",
+							Namespace: "default",
+						},
+						Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+					},
+					&v1.PersistentVolumeClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "PersistentVolumeClaim",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cache",
+							Namespace: "default",
+						},
+						Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+					},
+				},
+			},
+		},
 		{
 			name: "List resources with jq filter",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0:2]...)),
+				m.EXPECT().PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)),
 					gomock.Any()).Return(nil).Times(1)
 				f.printer = m
 				return nil
@@ -628,11 +832,63 @@ func TestUniversalHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "List nodes with label selector",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Action:        ActionList,
+					ResourceType:  getResource("node"),
+					LabelSelector: "gpu=true",
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&v1.Node{
+						TypeMeta: metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "gpu-node",
+							Labels: map[string]string{"gpu": "true"},
+						},
+					},
+					&v1.Node{
+						TypeMeta: metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "cpu-node",
+							Labels: map[string]string{"gpu": "false"},
+						},
+					},
+				},
+			},
+			want: want{
+				check: func(t *testing.T, f *fields, _ *shared) {
+					fakeClient, ok := f.client.(*dynamicfake.FakeDynamicClient)
+					require.True(t, ok)
+					actions := fakeClient.Actions()
+					require.Len(t, actions, 1)
+					listAction, ok := actions[0].(k8stesting.ListAction)
+					require.True(t, ok)
+					assert.Empty(t, listAction.GetNamespace(), "nodes are listed without namespace")
+					assert.Equal(t, "gpu=true", listAction.GetListRestrictions().Labels.String())
+				},
+			},
+		},
 		{
 			name: "List nodes with condition filter Ready=True",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -682,7 +938,10 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List nodes with multiple condition filters",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -735,7 +994,10 @@ func TestUniversalHandler(t *testing.T) {
 			name: "List nodes with custom condition filter",
 			prepare: func(t *testing.T, f *fields, s *shared) error {
 				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
-				m.EXPECT().PrintObjects(gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
 				f.printer = m
 				return nil
 			},
@@ -949,6 +1211,286 @@ func TestUniversalHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "List unhealthy deployments",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1:]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Health:       true,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "healthy",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &twoReplicas},
+						Status: appsv1.DeploymentStatus{ReadyReplicas: 2, AvailableReplicas: 2},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "not-ready",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &threeReplicas},
+						Status: appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "unavailable",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &oneReplica},
+						Status: appsv1.DeploymentStatus{ReadyReplicas: 1, UnavailableReplicas: 1},
+					},
+				},
+			},
+			want: want{
+				check: func(t *testing.T, _ *fields, s *shared) {
+					assert.Equal(t, "2 of 3 deployments unhealthy\n", s.errOut.String())
+				},
+			},
+		},
+		{
+			name: "List unhealthy daemonsets",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("daemonset"),
+					Health:       true,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&appsv1.DaemonSet{
+						TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "healthy",
+							Namespace: "default",
+						},
+						Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3},
+					},
+					&appsv1.DaemonSet{
+						TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "degraded",
+							Namespace: "default",
+						},
+						Status: appsv1.DaemonSetStatus{
+							DesiredNumberScheduled: 3,
+							NumberReady:            2,
+							NumberAvailable:        2,
+							NumberUnavailable:      1,
+						},
+					},
+				},
+			},
+			want: want{
+				check: func(t *testing.T, _ *fields, s *shared) {
+					assert.Equal(t, "1 of 2 daemonsets unhealthy\n", s.errOut.String())
+				},
+			},
+		},
+		{
+			name: "List unhealthy deployments matching jq",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Health:       true,
+					JQQuery: func() *gojq.Query {
+						q, err := pkg.PrepareQuery(".metadata.name | startswith(\"web-\")")
+						require.NoError(t, err)
+						return q
+					}(),
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "web-healthy",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &twoReplicas},
+						Status: appsv1.DeploymentStatus{ReadyReplicas: 2, AvailableReplicas: 2},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "web-not-ready",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &threeReplicas},
+						Status: appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "db-not-ready",
+							Namespace: "default",
+						},
+						Spec:   appsv1.DeploymentSpec{Replicas: &oneReplica},
+						Status: appsv1.DeploymentStatus{},
+					},
+				},
+			},
+			want: want{
+				check: func(t *testing.T, _ *fields, s *shared) {
+					assert.Equal(t, "1 of 2 deployments unhealthy\n", s.errOut.String())
+				},
+			},
+		},
+		{
+			name: "List stale deployments",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0])), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Stale:        true,
+				},
+			},
+			shared: shared{
+				resources: []runtime.Object{
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "behind",
+							Namespace:  "default",
+							Generation: 3,
+						},
+						Status: appsv1.DeploymentStatus{ObservedGeneration: 2},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "reconciled",
+							Namespace:  "default",
+							Generation: 3,
+						},
+						Status: appsv1.DeploymentStatus{ObservedGeneration: 3},
+					},
+					&appsv1.Deployment{
+						TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "never-observed",
+							Namespace:  "default",
+							Generation: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "List deployments by condition",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1:2]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Conditions:   []NodeCondition{{Type: "available", Status: "False"}},
+				},
+			},
+			shared: shared{resources: conditionedDeployments()},
+		},
+		{
+			name: "List deployments by condition with any status",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[0:2]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Conditions:   []NodeCondition{{Type: "Available", Status: AnyConditionStatus}},
+				},
+			},
+			shared: shared{resources: conditionedDeployments()},
+		},
+		{
+			name: "List deployments matching all conditions",
+			prepare: func(t *testing.T, f *fields, s *shared) error {
+				m := mocks.NewMockBatchPrinter(gomock.NewController(t))
+				m.EXPECT().
+					PrintObjects(gomock.Any(), gomock.InAnyOrder(toUL(t, s.resources[1:2]...)), gomock.Any()).
+					Return(nil).
+					Times(1)
+				f.printer = m
+				return nil
+			},
+			args: args{
+				options: ActionOptions{
+					Namespace:    "default",
+					Action:       ActionList,
+					ResourceType: getResource("deployment"),
+					Conditions:   []NodeCondition{{Type: "Available", Status: "False"}, {Type: "Progressing", Status: "True"}},
+				},
+			},
+			shared: shared{resources: conditionedDeployments()},
+		},
 	}
 
 	test := func(prepare func(*testing.T, *fields, *shared) error, args args, shared shared, want want) func(t *testing.T) {
@@ -981,6 +1523,7 @@ func TestUniversalHandler(t *testing.T) {
 			// Create a fake dynamic client with pre-populated resources
 			scheme := runtime.NewScheme()
 			require.NoError(t, v1.AddToScheme(scheme))
+			require.NoError(t, appsv1.AddToScheme(scheme))
 
 			ff.client = dynamicfake.NewSimpleDynamicClient(scheme, shared.resources...)
 
@@ -1018,3 +1561,167 @@ func TestUniversalHandler(t *testing.T) {
 		t.Run(tt.name, test(tt.prepare, tt.args, tt.shared, tt.want))
 	}
 }
+
+func TestUniversalHandler_NoMatchesNeverPrompts(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	annotate, err := ParseAnnotateFlag("team=platform")
+	require.NoError(t, err)
+
+	for _, action := range []Action{ActionDelete, ActionPatch, ActionAnnotate} {
+		t.Run(action.String(), func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1.AddToScheme(scheme))
+			client := dynamicfake.NewSimpleDynamicClient(scheme,
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+			)
+			handler := UniversalHandler{
+				opts: UniversalHandlerOptions{Client: client, Resource: configMapType},
+			}
+
+			// "y" would confirm the action if a prompt was shown
+			streams, in, out, errOut := genericclioptions.NewTestIOStreams()
+			in.WriteString("y\n")
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:    "default",
+				Action:       action,
+				NameRegex:    regexp.MustCompile("^missing$"),
+				Patch:        `{"data": {"key": "value"}}`,
+				Annotate:     annotate,
+				ResourceType: configMapType,
+				Streams:      &streams,
+			})
+			require.NoError(t, err)
+
+			assert.Empty(t, out.String())
+			assert.Empty(t, errOut.String(), "neither a preview nor a prompt is expected")
+			assert.Equal(t, "y\n", in.String(), "confirmation must not be read")
+			for _, a := range client.Actions() {
+				assert.Equal(t, "list", a.GetVerb())
+			}
+		})
+	}
+}
+
+func TestUniversalHandler_DeleteInAllNamespaces(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "staging"}},
+	)
+	handler := UniversalHandler{
+		opts: UniversalHandlerOptions{Client: client, Resource: configMapType},
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(t.Context(), ActionOptions{
+		Namespace:    "",
+		Action:       ActionDelete,
+		SkipConfirm:  true,
+		ResourceType: configMapType,
+		Streams:      &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted configmap settings\nDeleted configmap settings\n", out.String())
+
+	var deletedFrom []string
+	for _, a := range client.Actions() {
+		if a.GetVerb() == "delete" {
+			deletedFrom = append(deletedFrom, a.GetNamespace())
+		}
+	}
+	assert.ElementsMatch(t, []string{"default", "staging"}, deletedFrom)
+
+	remaining, err := client.Resource(configMapType.GroupVersionResource).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, remaining.Items)
+}
+
+func TestUniversalHandler_PatchInAllNamespaces(t *testing.T) {
+	configMapType := Resource{
+		GroupVersionResource: v1.SchemeGroupVersion.WithResource("configmaps"),
+		GroupVersionKind:     v1.SchemeGroupVersion.WithKind("ConfigMap"),
+		SingularName:         "configmap",
+		PluralName:           "configmaps",
+		IsNamespaced:         true,
+	}
+	annotate, err := ParseAnnotateFlag("team=platform")
+	require.NoError(t, err)
+
+	for _, action := range []Action{ActionPatch, ActionAnnotate} {
+		t.Run(action.String(), func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1.AddToScheme(scheme))
+			client := dynamicfake.NewSimpleDynamicClient(scheme,
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+				&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "staging"}},
+			)
+			handler := UniversalHandler{
+				opts: UniversalHandlerOptions{Client: client, Resource: configMapType},
+			}
+
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(t.Context(), ActionOptions{
+				Namespace:     "",
+				Action:        action,
+				Patch:         `{"metadata": {"annotations": {"team": "platform"}}}`,
+				PatchStrategy: k8s_types.MergePatchType,
+				Annotate:      annotate,
+				SkipConfirm:   true,
+				ResourceType:  configMapType,
+				Streams:       &streams,
+			})
+			require.NoError(t, err)
+
+			var patchedIn []string
+			for _, a := range client.Actions() {
+				if a.GetVerb() == "patch" {
+					patchedIn = append(patchedIn, a.GetNamespace())
+				}
+			}
+			assert.ElementsMatch(t, []string{"default", "staging"}, patchedIn)
+
+			for _, namespace := range []string{"default", "staging"} {
+				cm, err := client.Resource(configMapType.GroupVersionResource).Namespace(namespace).
+					Get(t.Context(), "settings", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"team": "platform"}, cm.GetAnnotations(), namespace)
+			}
+		})
+	}
+}
+
+// conditionedDeployments returns an available deployment, an unavailable one and one without conditions.
+func conditionedDeployments() []runtime.Object {
+	deployment := func(name string, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     appsv1.DeploymentStatus{Conditions: conditions},
+		}
+	}
+	return []runtime.Object{
+		deployment("available",
+			appsv1.DeploymentCondition{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+			appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue},
+		),
+		deployment("unavailable",
+			appsv1.DeploymentCondition{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse},
+			appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue},
+		),
+		deployment("new"),
+	}
+}
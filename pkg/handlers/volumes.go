@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Volume types accepted by the --volume-type flag.
+const (
+	VolumeTypeHostPath  = "hostPath"
+	VolumeTypeSecret    = "secret"
+	VolumeTypeConfigMap = "configMap"
+	VolumeTypePVC       = "pvc"
+)
+
+// ParseVolumeType converts the --volume-type flag value to one of the volume types, ignoring case.
+func ParseVolumeType(value string) (string, error) {
+	types := []string{VolumeTypeHostPath, VolumeTypeSecret, VolumeTypeConfigMap, VolumeTypePVC}
+	for _, volumeType := range types {
+		if strings.EqualFold(value, volumeType) {
+			return volumeType, nil
+		}
+	}
+	sort.Strings(types)
+	return "", fmt.Errorf("unknown volume type %q, must be one of: %s", value, strings.Join(types, ", "))
+}
+
+// volumeSource returns the type of the volume and the name of the object it refers to:
+// the secret, config map or claim name, or the path on the node for hostPath volumes.
+// Other volume types are returned as an empty type.
+func volumeSource(volume v1.Volume) (string, string) {
+	switch {
+	case volume.HostPath != nil:
+		return VolumeTypeHostPath, volume.HostPath.Path
+	case volume.Secret != nil:
+		return VolumeTypeSecret, volume.Secret.SecretName
+	case volume.ConfigMap != nil:
+		return VolumeTypeConfigMap, volume.ConfigMap.Name
+	case volume.PersistentVolumeClaim != nil:
+		return VolumeTypePVC, volume.PersistentVolumeClaim.ClaimName
+	default:
+		return "", ""
+	}
+}
+
+// hasVolume returns true if the pod has a volume of the type, if set, whose name
+// or referenced object name equals name, if set.
+func hasVolume(pod *v1.Pod, volumeType, name string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		sourceType, sourceName := volumeSource(volume)
+		if volumeType != "" && sourceType != volumeType {
+			continue
+		}
+		if name == "" || volume.Name == name || sourceName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// usesPVC returns true if the pod mounts the persistent volume claim,
+// either directly or as the claim created for its generic ephemeral volume.
+func usesPVC(pod *v1.Pod, claimName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+		// ephemeral volume claims are named <pod name>-<volume name>
+		if volume.Ephemeral != nil && pod.Name+"-"+volume.Name == claimName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseVolumeType(t *testing.T) {
+	volumeType, err := ParseVolumeType("HostPath")
+	require.NoError(t, err)
+	assert.Equal(t, VolumeTypeHostPath, volumeType)
+
+	_, err = ParseVolumeType("emptyDir")
+	require.EqualError(t, err, `unknown volume type "emptyDir", must be one of: configMap, hostPath, pvc, secret`)
+}
+
+func TestHasVolume(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "docker-sock", VolumeSource: v1.VolumeSource{
+					HostPath: &v1.HostPathVolumeSource{Path: "/var/run/docker.sock"},
+				}},
+				{Name: "tls", VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "web-tls"},
+				}},
+				{Name: "config", VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}},
+				}},
+				{Name: "data", VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"},
+				}},
+				{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		volumeType string
+		volumeName string
+		want       bool
+	}{
+		{name: "hostPath", volumeType: VolumeTypeHostPath, want: true},
+		{name: "hostPath by path", volumeType: VolumeTypeHostPath, volumeName: "/var/run/docker.sock", want: true},
+		{name: "secret by secret name", volumeType: VolumeTypeSecret, volumeName: "web-tls", want: true},
+		{name: "secret by volume name", volumeType: VolumeTypeSecret, volumeName: "tls", want: true},
+		{name: "other secret", volumeType: VolumeTypeSecret, volumeName: "api-tls", want: false},
+		{name: "configMap by name", volumeType: VolumeTypeConfigMap, volumeName: "web-config", want: true},
+		{name: "pvc by claim name", volumeType: VolumeTypePVC, volumeName: "web-data", want: true},
+		{name: "name of another type", volumeType: VolumeTypePVC, volumeName: "web-tls", want: false},
+		{name: "any type by name", volumeName: "web-config", want: true},
+		{name: "any type by unknown name", volumeName: "missing", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasVolume(pod, tt.volumeType, tt.volumeName))
+		})
+	}
+
+	assert.False(t, hasVolume(&v1.Pod{}, VolumeTypeHostPath, ""), "pods without volumes never match")
+}
+
+func TestUsesPVC(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"},
+				}},
+				{Name: "scratch", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{}}},
+				{Name: "web-logs", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+
+	assert.True(t, usesPVC(pod, "web-data"))
+	assert.True(t, usesPVC(pod, "web-1-scratch"), "claims of ephemeral volumes are named after the pod and volume")
+	assert.False(t, usesPVC(pod, "data"), "volume names are not claim names")
+	assert.False(t, usesPVC(pod, "web-logs"))
+	assert.False(t, usesPVC(pod, "api-data"))
+	assert.False(t, usesPVC(&v1.Pod{}, "web-data"), "pods without volumes never match")
+}
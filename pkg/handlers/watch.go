@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+// watchEvents calls handle with the type and object of every change event until the watch ends and returns
+// the last seen resource version, starting from the given one, so a closed watch can be resumed.
+// Bookmarks only advance the resource version and error events end the watch with the reported status.
+func watchEvents(
+	watcher watch.Interface,
+	kind string,
+	resourceVersion string,
+	handle func(watch.EventType, runtime.Object) error,
+) (string, error) {
+	defer watcher.Stop()
+	for event := range watcher.ResultChan() {
+		if event.Type == watch.Error {
+			return resourceVersion, fmt.Errorf("failed to watch %s: %w", kind, apierrors.FromObject(event.Object))
+		}
+		if obj, err := meta.Accessor(event.Object); err == nil && obj.GetResourceVersion() != "" {
+			resourceVersion = obj.GetResourceVersion()
+		}
+		if event.Type == watch.Bookmark {
+			continue
+		}
+		klog.V(4).InfoS("Received watch event", "kind", kind, "type", event.Type)
+		if err := handle(event.Type, event.Object); err != nil {
+			return resourceVersion, err
+		}
+	}
+	return resourceVersion, nil
+}
+
+// isWatchExpired returns true if the watch ended because its resource version is too old to resume from,
+// e.g. with 410 Gone after watching for hours.
+func isWatchExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// objectKey identifies an object by namespace/name.
+func objectKey(obj metav1.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// observedObjects records the last seen state of objects by namespace/name, so a re-list after an expired watch
+// prints only objects that changed in the meantime and reports the ones deleted in the meantime.
+type observedObjects[T metav1.Object] map[string]T
+
+// record stores the object and returns the type of its change since it was last seen, ADDED for a new object
+// or MODIFIED for a new resource version, and false if it did not change.
+func (o observedObjects[T]) record(obj T) (watch.EventType, bool) {
+	key := objectKey(obj)
+	previous, seen := o[key]
+	o[key] = obj
+	switch {
+	case !seen:
+		return watch.Added, true
+	case previous.GetResourceVersion() != obj.GetResourceVersion():
+		return watch.Modified, true
+	default:
+		return "", false
+	}
+}
+
+// forget stops tracking a deleted object.
+func (o observedObjects[T]) forget(obj T) {
+	delete(o, objectKey(obj))
+}
+
+// missing stops tracking and returns the objects whose keys were not listed, sorted by namespace/name.
+func (o observedObjects[T]) missing(listed map[string]bool) []T {
+	var keys []string
+	for key := range o {
+		if !listed[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	deleted := make([]T, len(keys))
+	for i, key := range keys {
+		deleted[i] = o[key]
+		delete(o, key)
+	}
+	return deleted
+}
+
+// watchWithRelist watches from the resource version until the context is cancelled. A watch closed by the server
+// is resumed from the last seen resource version. When it expires, relist lists the objects again, printing
+// the matching ones that changed or were deleted since they were last seen, and the watch resumes from the new list.
+func watchWithRelist(
+	ctx context.Context,
+	kind string,
+	resourceVersion string,
+	watchFrom func(resourceVersion string) (string, error),
+	relist func() (string, error),
+) error {
+	for {
+		lastVersion, err := watchFrom(resourceVersion)
+		if ctx.Err() != nil {
+			return nil // the watch was stopped by the caller
+		}
+		if err == nil {
+			klog.V(2).InfoS("Watch closed, resuming", "kind", kind, "resourceVersion", lastVersion)
+			resourceVersion = lastVersion
+			continue
+		}
+		if !isWatchExpired(err) {
+			return err
+		}
+		klog.V(2).InfoS("Watch expired, re-listing", "kind", kind, "resourceVersion", lastVersion, "err", err)
+		if resourceVersion, err = relist(); err != nil {
+			return err
+		}
+	}
+}
+
+// printPodEvent prints pods of a watch event with the handler printer, marked with the event type.
+func (p *PodHandler) printPodEvent(
+	ctx context.Context,
+	eventType watch.EventType,
+	pods []*v1.Pod,
+	out io.Writer,
+) error {
+	if p.nameOutput {
+		for _, pod := range pods {
+			if err := printers.PrintNameEvent(out, eventType, "pod", pod.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	unstructuredPods, err := podsToUnstructured(pods)
+	if err != nil {
+		return err
+	}
+	return printers.PrintEvent(ctx, p.printer, eventType, unstructuredPods, out)
+}
+
+// watch prints the matched pods as added, unless only changes are requested, and then every matching change
+// since the list until the context is cancelled, marked with the event type.
+func (p *PodHandler) watch(
+	ctx context.Context,
+	options ActionOptions,
+	matcher func(*v1.Pod) bool,
+	matchedPods []*v1.Pod,
+	resourceVersion string,
+) error {
+	if !options.WatchOnly && len(matchedPods) > 0 {
+		if err := p.printPodEvent(ctx, watch.Added, matchedPods, options.Streams.Out); err != nil {
+			return err
+		}
+	}
+
+	observed := observedObjects[*v1.Pod]{}
+	for _, pod := range matchedPods {
+		observed.record(pod)
+	}
+
+	watchFrom := func(resourceVersion string) (string, error) {
+		watcher, err := p.clientSet.CoreV1().Pods(options.Namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:       options.LabelSelector,
+			FieldSelector:       podFieldSelector(options),
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return resourceVersion, fmt.Errorf("failed to watch pods: %w", err)
+		}
+		return watchEvents(watcher, "pods", resourceVersion, func(eventType watch.EventType, obj runtime.Object) error {
+			pod, ok := obj.(*v1.Pod)
+			if !ok {
+				return nil
+			}
+			if eventType == watch.Deleted {
+				observed.forget(pod)
+			} else {
+				observed.record(pod)
+			}
+			if !matcher(pod) {
+				return nil
+			}
+			return p.printPodEvent(ctx, eventType, []*v1.Pod{pod}, options.Streams.Out)
+		})
+	}
+	relist := func() (string, error) {
+		pods, listedVersion, err := p.getAllPods(ctx, options)
+		if err != nil {
+			return "", err
+		}
+		listed := make(map[string]bool, len(pods))
+		for i := range pods {
+			listed[objectKey(&pods[i])] = true
+			eventType, changed := observed.record(&pods[i])
+			if !changed || !matcher(&pods[i]) {
+				continue
+			}
+			if err = p.printPodEvent(ctx, eventType, []*v1.Pod{&pods[i]}, options.Streams.Out); err != nil {
+				return "", err
+			}
+		}
+		for _, pod := range observed.missing(listed) {
+			if !matcher(pod) {
+				continue
+			}
+			if err = p.printPodEvent(ctx, watch.Deleted, []*v1.Pod{pod}, options.Streams.Out); err != nil {
+				return "", err
+			}
+		}
+		return listedVersion, nil
+	}
+	return watchWithRelist(ctx, "pods", resourceVersion, watchFrom, relist)
+}
+
+// watch prints the matched resources as added, unless only changes are requested, and then every matching change
+// since the list until the context is cancelled, marked with the event type.
+func (h *UniversalHandler) watch(
+	ctx context.Context,
+	resources dynamic.ResourceInterface,
+	options ActionOptions,
+	matchedItems []unstructured.Unstructured,
+	resourceVersion string,
+) error {
+	if !options.WatchOnly && len(matchedItems) > 0 {
+		if err := printers.PrintEvent(ctx, h.opts.Printer, watch.Added, matchedItems, options.Streams.Out); err != nil {
+			return err
+		}
+	}
+
+	observed := observedObjects[*unstructured.Unstructured]{}
+	for i := range matchedItems {
+		observed.record(&matchedItems[i])
+	}
+
+	watchFrom := func(resourceVersion string) (string, error) {
+		watcher, err := resources.Watch(ctx, metav1.ListOptions{
+			LabelSelector:       options.LabelSelector,
+			FieldSelector:       options.FieldSelector,
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return resourceVersion, fmt.Errorf("failed to watch %s: %w", h.opts.Resource.PluralName, err)
+		}
+		kind := h.opts.Resource.PluralName
+		return watchEvents(watcher, kind, resourceVersion, func(eventType watch.EventType, obj runtime.Object) error {
+			item, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return nil
+			}
+			if eventType == watch.Deleted {
+				observed.forget(item)
+			} else {
+				observed.record(item)
+			}
+			if !h.resourceMatches(*item, &options) {
+				return nil
+			}
+			matched := []unstructured.Unstructured{*item}
+			return printers.PrintEvent(ctx, h.opts.Printer, eventType, matched, options.Streams.Out)
+		})
+	}
+	relist := func() (string, error) {
+		items, listedVersion, err := h.getResources(ctx, resources, options)
+		if err != nil {
+			return "", err
+		}
+		listed := make(map[string]bool, len(items))
+		for i := range items {
+			listed[objectKey(&items[i])] = true
+			eventType, changed := observed.record(&items[i])
+			if !changed || !h.resourceMatches(items[i], &options) {
+				continue
+			}
+			err = printers.PrintEvent(ctx, h.opts.Printer, eventType, items[i:i+1], options.Streams.Out)
+			if err != nil {
+				return "", err
+			}
+		}
+		for _, item := range observed.missing(listed) {
+			if !h.resourceMatches(*item, &options) {
+				continue
+			}
+			deleted := []unstructured.Unstructured{*item}
+			if err = printers.PrintEvent(ctx, h.opts.Printer, watch.Deleted, deleted, options.Streams.Out); err != nil {
+				return "", err
+			}
+		}
+		return listedVersion, nil
+	}
+	return watchWithRelist(ctx, h.opts.Resource.PluralName, resourceVersion, watchFrom, relist)
+}
@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func failedPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1.PodStatus{Phase: v1.PodFailed},
+	}
+}
+
+// watchSequence serves the watchers one after another, like a server closing watches that are then resumed,
+// and cancels the context once they run out, since closed watches are resumed until the command is stopped.
+func watchSequence(cancel context.CancelFunc, watchers ...watch.Interface) k8stesting.WatchReactionFunc {
+	return func(k8stesting.Action) (bool, watch.Interface, error) {
+		if len(watchers) == 0 {
+			cancel()
+			return true, watch.NewEmptyWatch(), nil
+		}
+		next := watchers[0]
+		watchers = watchers[1:]
+		return true, next, nil
+	}
+}
+
+func TestPodHandler_Watch(t *testing.T) {
+	tests := []struct {
+		name      string
+		watchOnly bool
+		want      string
+	}{
+		{
+			name: "list and watch",
+			want: "pod/job-1 added\npod/job-2 added\npod/job-1 deleted\n",
+		},
+		{
+			name:      "watch only",
+			watchOnly: true,
+			want:      "pod/job-2 added\npod/job-1 deleted\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			clientSet := fake.NewClientset(failedPod("job-1"))
+			watcher := watch.NewFakeWithChanSize(4, false)
+			clientSet.PrependWatchReactor("pods", watchSequence(cancel, watcher))
+
+			running := failedPod("web-1")
+			running.Status.Phase = v1.PodRunning
+			watcher.Add(running)
+			watcher.Add(failedPod("job-2"))
+			watcher.Delete(failedPod("job-1"))
+			watcher.Stop()
+
+			handler := PodHandler{clientSet: clientSet, nameOutput: true}
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			err := handler.HandleAction(ctx, ActionOptions{
+				Namespace: "default",
+				Action:    ActionList,
+				PodStatus: v1.PodFailed,
+				Watch:     true,
+				WatchOnly: tt.watchOnly,
+				Streams:   &streams,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestUniversalHandler_WatchOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	watcher := watch.NewFakeWithChanSize(3, false)
+	client.PrependWatchReactor("deployments", watchSequence(cancel, watcher))
+
+	deployment := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetName(name)
+		obj.SetNamespace("default")
+		return obj
+	}
+	watcher.Add(deployment("api"))
+	watcher.Modify(deployment("web"))
+	watcher.Add(deployment("web-canary"))
+	watcher.Stop()
+
+	deploymentType := Resource{
+		GroupVersionResource: appsv1.SchemeGroupVersion.WithResource("deployments"),
+		GroupVersionKind:     appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		SingularName:         "deployment",
+		PluralName:           "deployments",
+		IsNamespaced:         true,
+	}
+	handler := UniversalHandler{
+		opts: UniversalHandlerOptions{
+			Client:   client,
+			Resource: deploymentType,
+			Printer:  printers.NewNamePrinter(printers.NamePrinterOptions{Resource: "deployment.apps"}),
+		},
+	}
+	out := &bytes.Buffer{}
+	err := handler.HandleAction(ctx, ActionOptions{
+		Namespace:    "default",
+		Action:       ActionList,
+		NameRegex:    regexp.MustCompile("^web"),
+		Watch:        true,
+		WatchOnly:    true,
+		ResourceType: deploymentType,
+		Streams:      &genericclioptions.IOStreams{Out: out, ErrOut: io.Discard},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "deployment.apps/web modified\ndeployment.apps/web-canary added\n", out.String())
+}
+
+// expiringWatchReactor serves a watch that fails with 410 Gone, calling beforeExpiry to change objects
+// before the re-list, and then the watchers of next in sequence.
+func expiringWatchReactor(
+	t *testing.T,
+	cancel context.CancelFunc,
+	beforeExpiry func(),
+	next ...watch.Interface,
+) k8stesting.WatchReactionFunc {
+	t.Helper()
+	expired := false
+	resumed := watchSequence(cancel, next...)
+	return func(action k8stesting.Action) (bool, watch.Interface, error) {
+		if expired {
+			return resumed(action)
+		}
+		expired = true
+		beforeExpiry()
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Error(&apierrors.NewResourceExpired("too old resource version: 1 (5)").ErrStatus)
+		watcher.Stop()
+		return true, watcher, nil
+	}
+}
+
+func TestPodHandler_WatchRelistsExpiredWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	clientSet := fake.NewClientset(failedPod("job-1"))
+	second := watch.NewFakeWithChanSize(1, false)
+	second.Add(failedPod("job-3"))
+	second.Stop()
+	clientSet.PrependWatchReactor("pods", expiringWatchReactor(t, cancel, func() {
+		// created while the watch was expiring, only the re-list can see it
+		require.NoError(t, clientSet.Tracker().Add(failedPod("job-2")))
+	}, second))
+
+	handler := PodHandler{clientSet: clientSet, nameOutput: true}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(ctx, ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		PodStatus: v1.PodFailed,
+		Watch:     true,
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/job-1 added\npod/job-2 added\npod/job-3 added\n", out.String(),
+		"unchanged job-1 is not printed again")
+
+	watches := 0
+	for _, action := range clientSet.Actions() {
+		if action.GetVerb() == "watch" {
+			watches++
+		}
+	}
+	assert.Equal(t, 3, watches, "the watch is re-established after the re-list and resumed after it is closed")
+}
+
+func TestUniversalHandler_WatchRelistsExpiredWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	client := dynamicfake.NewSimpleDynamicClient(scheme,
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	client.PrependWatchReactor("configmaps", expiringWatchReactor(t, cancel, func() {
+		// reactors run under the client lock, so objects are changed in the tracker directly
+		require.NoError(t, client.Tracker().Add(&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "web-canary", "namespace": "default"},
+		}}))
+		// deleted while the watch was expiring, only missing from the re-list
+		require.NoError(t, client.Tracker().Delete(configMapType.GroupVersionResource, "default", "web"))
+	}))
+
+	handler := UniversalHandler{
+		opts: UniversalHandlerOptions{
+			Client:   client,
+			Resource: configMapType,
+			Printer:  printers.NewNamePrinter(printers.NamePrinterOptions{Resource: "configmap"}),
+		},
+	}
+	out := &bytes.Buffer{}
+	err := handler.HandleAction(ctx, ActionOptions{
+		Namespace:    "default",
+		Action:       ActionList,
+		NameRegex:    regexp.MustCompile("^web"),
+		Watch:        true,
+		ResourceType: configMapType,
+		Streams:      &genericclioptions.IOStreams{Out: out, ErrOut: io.Discard},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "configmap/web added\nconfigmap/web-canary added\nconfigmap/web deleted\n", out.String())
+}
+
+func TestWatchEvents_Error(t *testing.T) {
+	watcher := watch.NewFakeWithChanSize(1, false)
+	watcher.Error(&metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonExpired,
+		Message: "too old resource version",
+	})
+
+	_, err := watchEvents(watcher, "pods", "", func(watch.EventType, runtime.Object) error {
+		t.Fatal("error events must not be handled")
+		return nil
+	})
+	require.EqualError(t, err, "failed to watch pods: too old resource version")
+}
+
+func TestPodHandler_WatchResumesClosedWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	withVersion := func(pod *v1.Pod, resourceVersion string) *v1.Pod {
+		pod.ResourceVersion = resourceVersion
+		return pod
+	}
+	first := watch.NewFakeWithChanSize(2, false)
+	first.Add(withVersion(failedPod("job-1"), "7"))
+	first.Action(watch.Bookmark, withVersion(&v1.Pod{}, "9"))
+	first.Stop()
+	second := watch.NewFakeWithChanSize(1, false)
+	second.Add(withVersion(failedPod("job-2"), "12"))
+	second.Stop()
+	clientSet := fake.NewClientset()
+	clientSet.PrependWatchReactor("pods", watchSequence(cancel, first, second))
+
+	handler := PodHandler{clientSet: clientSet, nameOutput: true}
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	err := handler.HandleAction(ctx, ActionOptions{
+		Namespace: "default",
+		Action:    ActionList,
+		Watch:     true,
+		WatchOnly: true,
+		Streams:   &streams,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pod/job-1 added\npod/job-2 added\n", out.String(), "events of the resumed watch are printed")
+
+	var versions []string
+	for _, action := range clientSet.Actions() {
+		if watchAction, ok := action.(k8stesting.WatchAction); ok {
+			versions = append(versions, watchAction.GetWatchRestrictions().ResourceVersion)
+		}
+	}
+	assert.Equal(t, []string{"1", "9", "12"}, versions, "closed watches resume from the last seen version")
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alikhil/kubectl-find/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// workloadReplicas holds the replica counts used to determine workload health.
+// Unavailable replicas are the desired ones that are not available, for every workload type.
+type workloadReplicas struct {
+	desired     int32
+	ready       int32
+	unavailable int32
+}
+
+func newWorkloadReplicas(desired, ready, available int32) workloadReplicas {
+	return workloadReplicas{
+		desired:     desired,
+		ready:       ready,
+		unavailable: max(desired-available, 0),
+	}
+}
+
+func (r workloadReplicas) isHealthy() bool {
+	return r.ready >= r.desired && r.unavailable <= 0
+}
+
+// IsWorkloadType returns true if the resource type supports health filtering.
+func IsWorkloadType(gvr schema.GroupVersionResource) bool {
+	switch gvr {
+	case DeploymentType, StatefulSetType, DaemonSetType:
+		return true
+	default:
+		return false
+	}
+}
+
+func getWorkloadReplicas(obj unstructured.Unstructured, gvr schema.GroupVersionResource) (workloadReplicas, error) {
+	switch gvr {
+	case DeploymentType:
+		deployment, err := toDeployment(obj)
+		if err != nil {
+			return workloadReplicas{}, err
+		}
+		return newWorkloadReplicas(
+			getReplicaCountOrDefault(deployment.Spec.Replicas),
+			deployment.Status.ReadyReplicas,
+			deployment.Status.AvailableReplicas,
+		), nil
+	case StatefulSetType:
+		statefulSet, err := toStatefulSet(obj)
+		if err != nil {
+			return workloadReplicas{}, err
+		}
+		return newWorkloadReplicas(
+			getReplicaCountOrDefault(statefulSet.Spec.Replicas),
+			statefulSet.Status.ReadyReplicas,
+			statefulSet.Status.AvailableReplicas,
+		), nil
+	case DaemonSetType:
+		daemonSet, err := toDaemonSet(obj)
+		if err != nil {
+			return workloadReplicas{}, err
+		}
+		return newWorkloadReplicas(
+			daemonSet.Status.DesiredNumberScheduled,
+			daemonSet.Status.NumberReady,
+			daemonSet.Status.NumberAvailable,
+		), nil
+	default:
+		return workloadReplicas{}, fmt.Errorf("unsupported workload type %q", gvr.String())
+	}
+}
+
+// isUnhealthyWorkload returns true if the workload has fewer ready replicas than desired or some replicas unavailable.
+// Workloads whose replicas cannot be read are not considered unhealthy.
+func isUnhealthyWorkload(resource unstructured.Unstructured, gvr schema.GroupVersionResource) bool {
+	replicas, err := getWorkloadReplicas(resource, gvr)
+	if err != nil {
+		return false
+	}
+	return !replicas.isHealthy()
+}
+
+// printHealthSummary reports how many of the workloads that passed the other filters are unhealthy.
+func printHealthSummary(out io.Writer, unhealthy, checked int, plural string) {
+	fmt.Fprintf(out, "%d of %d %s unhealthy\n", unhealthy, checked, plural)
+}
+
+func getHealthColumns(gvr schema.GroupVersionResource) []printers.Column {
+	return []printers.Column{
+		{
+			Header: "HEALTH",
+			Value: func(obj unstructured.Unstructured) string {
+				replicas, err := getWorkloadReplicas(obj, gvr)
+				if err != nil {
+					return UnknownStr
+				}
+				if replicas.isHealthy() {
+					return "Healthy"
+				}
+				return fmt.Sprintf("Unhealthy (%d/%d ready, %d unavailable)",
+					replicas.ready, replicas.desired, replicas.unavailable)
+			},
+		},
+	}
+}
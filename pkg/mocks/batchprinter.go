@@ -10,11 +10,13 @@
 package mocks
 
 import (
+	context "context"
 	io "io"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	watch "k8s.io/apimachinery/pkg/watch"
 )
 
 // MockBatchPrinter is a mock of BatchPrinter interface.
@@ -42,15 +44,53 @@ func (m *MockBatchPrinter) EXPECT() *MockBatchPrinterMockRecorder {
 }
 
 // PrintObjects mocks base method.
-func (m *MockBatchPrinter) PrintObjects(arg0 []unstructured.Unstructured, arg1 io.Writer) error {
+func (m *MockBatchPrinter) PrintObjects(arg0 context.Context, arg1 []unstructured.Unstructured, arg2 io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PrintObjects", arg0, arg1)
+	ret := m.ctrl.Call(m, "PrintObjects", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PrintObjects indicates an expected call of PrintObjects.
-func (mr *MockBatchPrinterMockRecorder) PrintObjects(arg0, arg1 any) *gomock.Call {
+func (mr *MockBatchPrinterMockRecorder) PrintObjects(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintObjects", reflect.TypeOf((*MockBatchPrinter)(nil).PrintObjects), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintObjects", reflect.TypeOf((*MockBatchPrinter)(nil).PrintObjects), arg0, arg1, arg2)
+}
+
+// MockEventPrinter is a mock of EventPrinter interface.
+type MockEventPrinter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPrinterMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPrinterMockRecorder is the mock recorder for MockEventPrinter.
+type MockEventPrinterMockRecorder struct {
+	mock *MockEventPrinter
+}
+
+// NewMockEventPrinter creates a new mock instance.
+func NewMockEventPrinter(ctrl *gomock.Controller) *MockEventPrinter {
+	mock := &MockEventPrinter{ctrl: ctrl}
+	mock.recorder = &MockEventPrinterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPrinter) EXPECT() *MockEventPrinterMockRecorder {
+	return m.recorder
+}
+
+// PrintEvent mocks base method.
+func (m *MockEventPrinter) PrintEvent(ctx context.Context, eventType watch.EventType, objects []unstructured.Unstructured, out io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrintEvent", ctx, eventType, objects, out)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrintEvent indicates an expected call of PrintEvent.
+func (mr *MockEventPrinterMockRecorder) PrintEvent(ctx, eventType, objects, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintEvent", reflect.TypeOf((*MockEventPrinter)(nil).PrintEvent), ctx, eventType, objects, out)
 }
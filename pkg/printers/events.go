@@ -0,0 +1,65 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// PrintEvent prints objects of a watch event, marked with the event type if the printer is an EventPrinter.
+func PrintEvent(
+	ctx context.Context,
+	printer BatchPrinter,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	if eventPrinter, ok := printer.(EventPrinter); ok {
+		return eventPrinter.PrintEvent(ctx, eventType, objects, out)
+	}
+	return printer.PrintObjects(ctx, objects, out)
+}
+
+// tableStream renders rows of watch events as they come, prefixed with an EVENT column,
+// and prints the header only once, like `kubectl get --watch --output-watch-events` does.
+// Columns are as wide as needed for the first rendered rows and grow for longer values.
+type tableStream struct {
+	widths []int
+}
+
+func (s *tableStream) render(out io.Writer, eventType watch.EventType, headers []string, data [][]string) error {
+	lines := make([][]string, 0, len(data)+1)
+	if s.widths == nil {
+		lines = append(lines, append([]string{"EVENT"}, headers...))
+		s.widths = []int{len(watch.Modified)} // the longest event type, so the column does not grow
+	}
+	for _, row := range data {
+		lines = append(lines, append([]string{string(eventType)}, row...))
+	}
+
+	for _, line := range lines {
+		for i, cell := range line {
+			if i == len(s.widths) {
+				s.widths = append(s.widths, 0)
+			}
+			s.widths[i] = max(s.widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	for _, line := range lines {
+		var b strings.Builder
+		for i, cell := range line {
+			// the same padding as RenderTable uses
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", s.widths[i]-utf8.RuneCountInString(cell)+3))
+		}
+		if _, err := fmt.Fprintln(out, b.String()); err != nil {
+			return fmt.Errorf("failed to write table row: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,13 +1,20 @@
 package printers
 
 import (
+	"context"
 	"io"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 //go:generate go tool mockgen -source $GOFILE -destination ../mocks/batchprinter.go -package=mocks
 
 type BatchPrinter interface {
-	PrintObjects([]unstructured.Unstructured, io.Writer) error
+	PrintObjects(context.Context, []unstructured.Unstructured, io.Writer) error
+}
+
+// EventPrinter prints objects of watch events marked with the event type, e.g. to tell deletions from additions.
+type EventPrinter interface {
+	PrintEvent(ctx context.Context, eventType watch.EventType, objects []unstructured.Unstructured, out io.Writer) error
 }
@@ -0,0 +1,159 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type JSONPrinterOptions struct {
+	// GroupVersionKind of matched objects, used when objects have no apiVersion and kind set (e.g. typed pods)
+	GroupVersionKind schema.GroupVersionKind
+	// CanonicalOrder prints top-level keys in canonicalKeys order, like kubectl prints typed objects,
+	// instead of sorting all of them alphabetically
+	CanonicalOrder bool
+}
+
+// canonicalKeys are the top-level keys printed first with CanonicalOrder, in this order.
+//
+//nolint:gochecknoglobals
+var canonicalKeys = []string{"apiVersion", "kind", "metadata", "spec", "status"}
+
+// RawPrinter prints the only matched object as indented JSON, exactly as returned by the API server,
+// including status and managed fields.
+type RawPrinter struct {
+	options JSONPrinterOptions
+}
+
+// NewRawPrinter creates a printer that dumps a single matched object.
+func NewRawPrinter(options JSONPrinterOptions) BatchPrinter {
+	return &RawPrinter{
+		options: options,
+	}
+}
+
+func (p *RawPrinter) PrintObjects(_ context.Context, objects []unstructured.Unstructured, out io.Writer) error {
+	if len(objects) != 1 {
+		return fmt.Errorf("raw output requires exactly one matched object, but %d matched; "+
+			"use jsonl output to print all of them", len(objects))
+	}
+	data, err := json.MarshalIndent(p.options.encodable(objects[0]), "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", objects[0].GetName(), err)
+	}
+	if _, err = fmt.Fprintf(out, "%s\n", data); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return nil
+}
+
+// JSONLinesPrinter prints every matched object as compact JSON on its own line.
+type JSONLinesPrinter struct {
+	options JSONPrinterOptions
+}
+
+// NewJSONLinesPrinter creates a printer that writes matched objects as JSON lines.
+func NewJSONLinesPrinter(options JSONPrinterOptions) BatchPrinter {
+	return &JSONLinesPrinter{
+		options: options,
+	}
+}
+
+func (p *JSONLinesPrinter) PrintObjects(_ context.Context, objects []unstructured.Unstructured, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	for _, obj := range objects {
+		if err := encoder.Encode(p.options.encodable(obj)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// PrintEvent prints every object wrapped in its watch event, {"type":"DELETED","object":{...}},
+// the same way `kubectl get --watch --output-watch-events -o json` does.
+func (p *JSONLinesPrinter) PrintEvent(
+	_ context.Context,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	encoder := json.NewEncoder(out)
+	for _, obj := range objects {
+		event := struct {
+			Type   watch.EventType `json:"type"`
+			Object interface{}     `json:"object"`
+		}{Type: eventType, Object: p.options.encodable(obj)}
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to write %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// withTypeMeta returns the object content with apiVersion and kind set, without modifying obj.
+func (o JSONPrinterOptions) withTypeMeta(obj unstructured.Unstructured) map[string]interface{} {
+	if obj.GetKind() != "" || o.GroupVersionKind.Empty() {
+		return obj.Object
+	}
+	content := maps.Clone(obj.Object)
+	content["apiVersion"], content["kind"] = o.GroupVersionKind.ToAPIVersionAndKind()
+	return content
+}
+
+// encodable returns the object content to encode, ordered as requested by the options.
+func (o JSONPrinterOptions) encodable(obj unstructured.Unstructured) interface{} {
+	content := o.withTypeMeta(obj)
+	if o.CanonicalOrder {
+		return canonicalObject(content)
+	}
+	return content
+}
+
+// canonicalObject encodes canonicalKeys first and the remaining keys sorted alphabetically.
+// Nested maps keep the alphabetical order of encoding/json.
+type canonicalObject map[string]interface{}
+
+func (o canonicalObject) MarshalJSON() ([]byte, error) {
+	keys := make([]string, 0, len(o))
+	for _, key := range canonicalKeys {
+		if _, ok := o[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	rest := make([]string, 0, len(o)-len(keys))
+	for key := range o {
+		if !slices.Contains(canonicalKeys, key) {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range append(keys, rest...) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
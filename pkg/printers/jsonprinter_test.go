@@ -0,0 +1,140 @@
+package printers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var podGVK = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+func typedPod(name string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":          name,
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"status": map[string]interface{}{"phase": "Running"},
+	}}
+}
+
+func TestRawPrinter_SingleMatch(t *testing.T) {
+	pod := typedPod("web-1")
+	out := &bytes.Buffer{}
+	require.NoError(t, NewRawPrinter(JSONPrinterOptions{GroupVersionKind: podGVK}).
+		PrintObjects(t.Context(), []unstructured.Unstructured{pod}, out))
+
+	assert.Equal(t, `{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+        "managedFields": [
+            {
+                "manager": "kubectl"
+            }
+        ],
+        "name": "web-1"
+    },
+    "status": {
+        "phase": "Running"
+    }
+}
+`, out.String())
+	assert.Empty(t, pod.GetKind(), "matched object must not be modified")
+}
+
+func TestRawPrinter_MultipleMatches(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := NewRawPrinter(JSONPrinterOptions{GroupVersionKind: podGVK}).
+		PrintObjects(t.Context(), []unstructured.Unstructured{typedPod("web-1"), typedPod("web-2")}, out)
+
+	require.EqualError(t, err,
+		"raw output requires exactly one matched object, but 2 matched; use jsonl output to print all of them")
+	assert.Empty(t, out.String())
+}
+
+func TestJSONLinesPrinter(t *testing.T) {
+	configMap := unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("settings")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, NewJSONLinesPrinter(JSONPrinterOptions{GroupVersionKind: podGVK}).
+		PrintObjects(t.Context(), []unstructured.Unstructured{typedPod("web-1"), configMap}, out))
+
+	assert.Equal(t,
+		`{"apiVersion":"v1","kind":"Pod","metadata":{"managedFields":[{"manager":"kubectl"}],"name":"web-1"},"status":{"phase":"Running"}}`+"\n"+
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"}}`+"\n",
+		out.String())
+}
+
+func TestJSONLinesPrinter_CanonicalOrder(t *testing.T) {
+	deployment := unstructured.Unstructured{Object: map[string]interface{}{
+		"status":     map[string]interface{}{"replicas": int64(1)},
+		"spec":       map[string]interface{}{"replicas": int64(1), "paused": false},
+		"metadata":   map[string]interface{}{"name": "web"},
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+	}}
+	configMap := unstructured.Unstructured{Object: map[string]interface{}{
+		"immutable": true,
+		"data":      map[string]interface{}{"key": "value"},
+		"metadata":  map[string]interface{}{"name": "settings"},
+	}}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, NewJSONLinesPrinter(JSONPrinterOptions{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		CanonicalOrder:   true,
+	}).PrintObjects(t.Context(), []unstructured.Unstructured{deployment, configMap}, out))
+
+	assert.Equal(t,
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web"},"spec":{"paused":false,"replicas":1},"status":{"replicas":1}}`+"\n"+
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"},"data":{"key":"value"},"immutable":true}`+"\n",
+		out.String())
+}
+
+func TestRawPrinter_CanonicalOrder(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, NewRawPrinter(JSONPrinterOptions{GroupVersionKind: podGVK, CanonicalOrder: true}).
+		PrintObjects(t.Context(), []unstructured.Unstructured{{Object: map[string]interface{}{
+			"status":   map[string]interface{}{"phase": "Running"},
+			"spec":     map[string]interface{}{"nodeName": "node-1"},
+			"metadata": map[string]interface{}{"name": "web-1"},
+		}}}, out))
+
+	assert.Equal(t, `{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+        "name": "web-1"
+    },
+    "spec": {
+        "nodeName": "node-1"
+    },
+    "status": {
+        "phase": "Running"
+    }
+}
+`, out.String())
+}
+
+func TestJSONLinesPrinter_PrintEvent(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := NewJSONLinesPrinter(JSONPrinterOptions{GroupVersionKind: podGVK}).(EventPrinter)
+	deleted := []unstructured.Unstructured{typedPod("web-1")}
+	require.NoError(t, printer.PrintEvent(t.Context(), watch.Deleted, deleted, out))
+
+	assert.JSONEq(t, `{"type":"DELETED","object":{
+		"apiVersion":"v1",
+		"kind":"Pod",
+		"metadata":{"managedFields":[{"manager":"kubectl"}],"name":"web-1"},
+		"status":{"phase":"Running"}
+	}}`, out.String())
+}
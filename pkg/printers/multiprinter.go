@@ -0,0 +1,64 @@
+package printers
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Sink is a printer together with the output it writes to.
+type Sink struct {
+	Printer BatchPrinter
+	Out     io.Writer // output of the printer, e.g. a file; nil writes to the output given to PrintObjects
+}
+
+// MultiPrinter fans matched objects out to several printers,
+// e.g. a table to stdout and JSON lines to a file at the same time.
+type MultiPrinter struct {
+	sinks []Sink
+}
+
+// NewMultiPrinter creates a printer that prints objects with every sink, in the given order.
+func NewMultiPrinter(sinks ...Sink) BatchPrinter {
+	return &MultiPrinter{
+		sinks: sinks,
+	}
+}
+
+// PrintObjects prints the objects with all sinks, even if some of them fail, and returns their errors joined.
+func (p *MultiPrinter) PrintObjects(ctx context.Context, objects []unstructured.Unstructured, out io.Writer) error {
+	var errs []error
+	for _, sink := range p.sinks {
+		sinkOut := sink.Out
+		if sinkOut == nil {
+			sinkOut = out
+		}
+		if err := sink.Printer.PrintObjects(ctx, objects, sinkOut); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PrintEvent prints the objects of a watch event with all sinks, marked with the event type by sinks that support it.
+func (p *MultiPrinter) PrintEvent(
+	ctx context.Context,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	var errs []error
+	for _, sink := range p.sinks {
+		sinkOut := sink.Out
+		if sinkOut == nil {
+			sinkOut = out
+		}
+		if err := PrintEvent(ctx, sink.Printer, eventType, objects, sinkOut); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
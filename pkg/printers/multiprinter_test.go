@@ -0,0 +1,42 @@
+package printers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMultiPrinter_AllSinksReceiveObjects(t *testing.T) {
+	pods := []unstructured.Unstructured{typedPod("web-1"), typedPod("web-2")}
+	out, file := &bytes.Buffer{}, &bytes.Buffer{}
+
+	printer := NewMultiPrinter(
+		Sink{Printer: NewNamePrinter(NamePrinterOptions{Resource: "pod"})},
+		Sink{Printer: NewJSONLinesPrinter(JSONPrinterOptions{GroupVersionKind: podGVK}), Out: file},
+	)
+	require.NoError(t, printer.PrintObjects(t.Context(), pods, out))
+
+	assert.Equal(t, "pod/web-1\npod/web-2\n", out.String())
+	assert.Equal(t,
+		`{"apiVersion":"v1","kind":"Pod","metadata":{"managedFields":[{"manager":"kubectl"}],"name":"web-1"},"status":{"phase":"Running"}}`+"\n"+
+			`{"apiVersion":"v1","kind":"Pod","metadata":{"managedFields":[{"manager":"kubectl"}],"name":"web-2"},"status":{"phase":"Running"}}`+"\n",
+		file.String())
+}
+
+func TestMultiPrinter_FailingSinkDoesNotStopOthers(t *testing.T) {
+	pods := []unstructured.Unstructured{typedPod("web-1"), typedPod("web-2")}
+	out, file := &bytes.Buffer{}, &bytes.Buffer{}
+
+	printer := NewMultiPrinter(
+		Sink{Printer: NewRawPrinter(JSONPrinterOptions{})},
+		Sink{Printer: NewNamePrinter(NamePrinterOptions{Resource: "pod"}), Out: file},
+	)
+	err := printer.PrintObjects(t.Context(), pods, out)
+
+	require.ErrorContains(t, err, "raw output requires exactly one matched object")
+	assert.Empty(t, out.String())
+	assert.Equal(t, "pod/web-1\npod/web-2\n", file.String())
+}
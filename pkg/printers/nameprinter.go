@@ -0,0 +1,67 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type NamePrinterOptions struct {
+	Resource string // resource prefix of printed names, e.g. "pod" or "deployment.apps"
+}
+
+// NamePrinter prints only resource names in resource/name form, the same way `kubectl get -o name` does.
+type NamePrinter struct {
+	options NamePrinterOptions
+}
+
+// NewNamePrinter creates a printer that prints names of matched objects.
+func NewNamePrinter(options NamePrinterOptions) BatchPrinter {
+	return &NamePrinter{
+		options: options,
+	}
+}
+
+func (p *NamePrinter) PrintObjects(_ context.Context, objects []unstructured.Unstructured, out io.Writer) error {
+	for _, obj := range objects {
+		if err := PrintName(out, p.options.Resource, obj.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintEvent prints names followed by the lowercase event type, e.g. "pod/web-1 deleted".
+func (p *NamePrinter) PrintEvent(
+	_ context.Context,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	for _, obj := range objects {
+		if err := PrintNameEvent(out, eventType, p.options.Resource, obj.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintName writes a single resource/name line.
+func PrintName(out io.Writer, resource, name string) error {
+	if _, err := fmt.Fprintf(out, "%s/%s\n", resource, name); err != nil {
+		return fmt.Errorf("failed to write name: %w", err)
+	}
+	return nil
+}
+
+// PrintNameEvent writes a single resource/name line followed by the lowercase event type.
+func PrintNameEvent(out io.Writer, eventType watch.EventType, resource, name string) error {
+	if _, err := fmt.Fprintf(out, "%s/%s %s\n", resource, name, strings.ToLower(string(eventType))); err != nil {
+		return fmt.Errorf("failed to write name: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TableFetcher requests a page of the server-side Table representation of resources in a namespace,
+// continuing from the token of the previous page. An empty namespace is used for cluster-scoped resources.
+type TableFetcher func(ctx context.Context, namespace, continueToken string) (*metav1.Table, error)
+
+type ServerTablePrinterOptions struct {
+	PrefixColumns     []Column // columns to add to the table before NAMESPACE
+	ShowNamespace     bool
+	Wide              bool // also print columns with priority above 0, like `kubectl get -o wide`
+	Fetcher           TableFetcher
+	LabelColumns      []Column   // additional columns to add to the table after server columns
+	AnnotationColumns []Column   // additional columns to add to the table after LabelColumns
+	Rows              *TableRows // if set, rows are collected there instead of being rendered
+}
+
+// ServerTablePrinter prints the columns defined by the API server, the same way `kubectl get` does.
+type ServerTablePrinter struct {
+	options ServerTablePrinterOptions
+	events  tableStream // rows of watch events printed so far
+}
+
+// NewServerTablePrinter creates a printer that renders server-defined columns for matched objects.
+func NewServerTablePrinter(options ServerTablePrinterOptions) BatchPrinter {
+	return &ServerTablePrinter{
+		options: options,
+	}
+}
+
+func (p *ServerTablePrinter) PrintObjects(
+	ctx context.Context,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	if len(objects) == 0 {
+		return nil // nothing to print
+	}
+
+	headers, data, err := p.table(ctx, objects, false)
+	if err != nil {
+		return err
+	}
+	return p.options.Rows.renderOrCollect(out, headers, data)
+}
+
+// PrintEvent prints the objects with an EVENT column, printing the header only with the first event.
+// Deleted objects are no longer returned by the server, so only their names are printed.
+func (p *ServerTablePrinter) PrintEvent(
+	ctx context.Context,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	if len(objects) == 0 {
+		return nil // nothing to print
+	}
+
+	headers, data, err := p.table(ctx, objects, true)
+	if err != nil {
+		return err
+	}
+	return p.events.render(out, eventType, headers, data)
+}
+
+// table returns the headers and the rows of the objects. Objects missing from the server table are skipped,
+// unless keepMissing is set, then only their name is printed.
+func (p *ServerTablePrinter) table(
+	ctx context.Context,
+	objects []unstructured.Unstructured,
+	keepMissing bool,
+) ([]string, [][]string, error) {
+	objectsByUID := make(map[k8s_types.UID]int, len(objects))
+	namespaces := map[string]struct{}{}
+	for i, obj := range objects {
+		objectsByUID[obj.GetUID()] = i
+		namespaces[obj.GetNamespace()] = struct{}{}
+	}
+	sortedNamespaces := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		sortedNamespaces = append(sortedNamespaces, ns)
+	}
+	sort.Strings(sortedNamespaces)
+
+	var columnDefinitions []metav1.TableColumnDefinition
+	rows := make([][]interface{}, len(objects))
+	for _, ns := range sortedNamespaces {
+		for continueToken := ""; ; {
+			table, err := p.options.Fetcher(ctx, ns, continueToken)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to fetch server table: %w", err)
+			}
+			if len(table.ColumnDefinitions) > 0 {
+				columnDefinitions = table.ColumnDefinitions
+			}
+			for _, row := range table.Rows {
+				meta := metav1.PartialObjectMetadata{}
+				if err = json.Unmarshal(row.Object.Raw, &meta); err != nil {
+					return nil, nil, fmt.Errorf("failed to decode table row object: %w", err)
+				}
+				if i, found := objectsByUID[meta.UID]; found {
+					rows[i] = row.Cells
+				}
+			}
+			if continueToken = table.Continue; continueToken == "" {
+				break
+			}
+		}
+	}
+
+	headers := []string{}
+	for _, col := range p.options.PrefixColumns {
+		headers = append(headers, col.Header)
+	}
+	if p.options.ShowNamespace {
+		headers = append(headers, "NAMESPACE")
+	}
+	for _, def := range columnDefinitions {
+		if p.columnVisible(def) {
+			headers = append(headers, strings.ToUpper(def.Name))
+		}
+	}
+	for _, col := range p.options.LabelColumns {
+		headers = append(headers, col.Header)
+	}
+	for _, col := range p.options.AnnotationColumns {
+		headers = append(headers, col.Header)
+	}
+
+	data := make([][]string, 0, len(objects))
+	for i, obj := range objects {
+		if rows[i] == nil && !keepMissing {
+			// object was deleted or not returned by the server since it was listed
+			continue
+		}
+		row := make([]string, 0, len(headers))
+		for _, col := range p.options.PrefixColumns {
+			row = append(row, col.Value(obj))
+		}
+		if p.options.ShowNamespace {
+			row = append(row, obj.GetNamespace())
+		}
+		for j, def := range columnDefinitions {
+			if !p.columnVisible(def) {
+				continue
+			}
+			switch {
+			case j < len(rows[i]) && rows[i][j] == nil:
+				row = append(row, "") // e.g. a CRD printer column whose path is not set
+			case j < len(rows[i]):
+				row = append(row, fmt.Sprint(rows[i][j]))
+			case rows[i] == nil && def.Name == "Name":
+				row = append(row, obj.GetName())
+			default:
+				row = append(row, "")
+			}
+		}
+		for _, col := range p.options.LabelColumns {
+			row = append(row, col.Value(obj))
+		}
+		for _, col := range p.options.AnnotationColumns {
+			row = append(row, col.Value(obj))
+		}
+		data = append(data, row)
+	}
+	return headers, data, nil
+}
+
+// columnVisible returns whether the server column is printed, columns with priority above 0 only in wide output.
+func (p *ServerTablePrinter) columnVisible(def metav1.TableColumnDefinition) bool {
+	return def.Priority == 0 || p.options.Wide
+}
@@ -0,0 +1,94 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+func tableRow(name string, uid k8s_types.UID, cells ...interface{}) metav1.TableRow {
+	raw := `{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1","metadata":{"name":"` +
+		name + `","uid":"` + string(uid) + `"}}`
+	return metav1.TableRow{
+		Cells:  append([]interface{}{name}, cells...),
+		Object: runtime.RawExtension{Raw: []byte(raw)},
+	}
+}
+
+func TestServerTablePrinter_PrintsOnlyMatchedRows(t *testing.T) {
+	fetcher := func(_ context.Context, namespace, _ string) (*metav1.Table, error) {
+		assert.Equal(t, "default", namespace)
+		return &metav1.Table{
+			ColumnDefinitions: []metav1.TableColumnDefinition{
+				{Name: "Name"},
+				{Name: "Ready"},
+				{Name: "Node", Priority: 1},
+			},
+			Rows: []metav1.TableRow{
+				tableRow("web-1", "uid-1", "1/1", "node-a"),
+				tableRow("db-1", "uid-2", "0/1", "node-b"),
+			},
+		}, nil
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetName("web-1")
+	obj.SetNamespace("default")
+	obj.SetUID("uid-1")
+
+	printer := NewServerTablePrinter(ServerTablePrinterOptions{Fetcher: fetcher})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{obj}, out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "READY"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"web-1", "1/1"}, strings.Fields(lines[1]))
+}
+
+func TestServerTablePrinter_PagesWideAndNullCells(t *testing.T) {
+	columns := []metav1.TableColumnDefinition{
+		{Name: "Name"},
+		{Name: "Version"},
+		{Name: "Node", Priority: 1},
+	}
+	pages := map[string]*metav1.Table{
+		"": {
+			ListMeta:          metav1.ListMeta{Continue: "page-2"},
+			ColumnDefinitions: columns,
+			Rows:              []metav1.TableRow{tableRow("web-1", "uid-1", nil, "node-a")},
+		},
+		"page-2": {
+			ColumnDefinitions: columns,
+			Rows:              []metav1.TableRow{tableRow("web-2", "uid-2", "v2", "node-b")},
+		},
+	}
+	var tokens []string
+	fetcher := func(_ context.Context, _, continueToken string) (*metav1.Table, error) {
+		tokens = append(tokens, continueToken)
+		return pages[continueToken], nil
+	}
+
+	objects := make([]unstructured.Unstructured, 2)
+	for i, uid := range []k8s_types.UID{"uid-1", "uid-2"} {
+		objects[i].SetUID(uid)
+		objects[i].SetNamespace("default")
+	}
+
+	out := &bytes.Buffer{}
+	printer := NewServerTablePrinter(ServerTablePrinterOptions{Wide: true, Fetcher: fetcher})
+	require.NoError(t, printer.PrintObjects(t.Context(), objects, out))
+
+	assert.Equal(t, []string{"", "page-2"}, tokens, "all pages are fetched")
+	assert.Equal(t, "NAME    VERSION   NODE     \n"+
+		"web-1             node-a   \n"+
+		"web-2   v2        node-b   \n", out.String(), "null cells are empty, wide columns are printed")
+}
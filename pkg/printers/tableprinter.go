@@ -1,8 +1,11 @@
 package printers
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
@@ -10,18 +13,26 @@ import (
 	"github.com/olekukonko/tablewriter/tw"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type TablePrinterOptions struct {
+	PrefixColumns     []Column // columns to add to the table before NAMESPACE, kept with CustomColumns
 	ShowNamespace     bool
 	AdditionalColumns []Column // additional columns to add to the table after NAME
 	SuffixColumns     []Column // additional columns to add to the table after AGE but before labels
 	LabelColumns      []Column // additional columns to add to the table after SuffixColumns
 	AnnotationColumns []Column // additional columns to add to the table after LabelColumns
+	CustomColumns     []Column // if set, only these columns are printed
+	SelectColumns     []string // if set, only columns with these headers (case-insensitive) are printed, in this order
+	HideColumns       []string // columns with these headers (case-insensitive) are not printed, unknown headers are ignored
+
+	Rows *TableRows // if set, rows are collected there instead of being rendered
 }
 
 type TablePrinter struct {
 	options TablePrinterOptions
+	events  tableStream // rows of watch events printed so far
 }
 
 type Column struct {
@@ -37,46 +48,106 @@ func NewTablePrinter(options TablePrinterOptions) BatchPrinter {
 	return printer
 }
 
-func (p *TablePrinter) PrintObjects(objects []unstructured.Unstructured, out io.Writer) error {
+func (p *TablePrinter) PrintObjects(_ context.Context, objects []unstructured.Unstructured, out io.Writer) error {
 	if len(objects) == 0 {
 		return nil // nothing to print
 	}
 
-	table := tablewriter.NewTable(out,
-		// tell render not to render any lines and separators
-		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
-			Borders: tw.BorderNone,
-			Settings: tw.Settings{
-				Separators: tw.SeparatorsNone,
-				Lines:      tw.LinesNone,
-			},
-		})),
+	headers, data, err := p.table(objects)
+	if err != nil {
+		return err
+	}
+	return p.options.Rows.renderOrCollect(out, headers, data)
+}
 
-		// Set general configuration
-		tablewriter.WithConfig(
-			tablewriter.Config{
-				Header: tw.CellConfig{
-					Formatting: tw.CellFormatting{
-						Alignment:  tw.AlignLeft, // force alignment for header
-						AutoFormat: tw.Off,
-					},
-					Padding: tw.CellPadding{Global: tw.Padding{Right: "   "}},
-				},
-				Row: tw.CellConfig{
-					Formatting: tw.CellFormatting{
-						Alignment: tw.AlignLeft, // force alightment for body
-					},
+// PrintEvent prints the objects with an EVENT column, printing the header only with the first event.
+func (p *TablePrinter) PrintEvent(
+	_ context.Context,
+	eventType watch.EventType,
+	objects []unstructured.Unstructured,
+	out io.Writer,
+) error {
+	if len(objects) == 0 {
+		return nil // nothing to print
+	}
 
-					// remove all padding in a in all cells
-					Padding: tw.CellPadding{Global: tw.Padding{Right: "   "}},
-				},
-			},
-		),
-	)
+	headers, data, err := p.table(objects)
+	if err != nil {
+		return err
+	}
+	return p.events.render(out, eventType, headers, data)
+}
+
+// table returns the headers and the rows of the objects.
+func (p *TablePrinter) table(objects []unstructured.Unstructured) ([]string, [][]string, error) {
+	columns, err := p.options.columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := make([]string, len(columns))
+	for i := range columns {
+		headers[i] = columns[i].Header
+	}
+
+	// all rows share a single backing array to avoid an allocation per row
+	cells := make([]string, len(objects)*len(columns))
+	data := make([][]string, len(objects))
+	for i, obj := range objects {
+		row := cells[i*len(columns) : (i+1)*len(columns) : (i+1)*len(columns)]
+		for j, col := range columns {
+			row[j] = col.Value(obj)
+		}
+		data[i] = row
+	}
+	return headers, data, nil
+}
+
+// Validate checks that all selected columns are available.
+func (o TablePrinterOptions) Validate() error {
+	_, err := o.columns()
+	return err
+}
+
+// columns assembles the list of columns to print, in order, applying column selection.
+func (o TablePrinterOptions) columns() ([]Column, error) {
+	columns := o.allColumns()
+	if len(o.HideColumns) > 0 {
+		columns = slices.DeleteFunc(columns, func(col Column) bool {
+			return slices.ContainsFunc(o.HideColumns, func(name string) bool {
+				return strings.EqualFold(col.Header, strings.TrimSpace(name))
+			})
+		})
+	}
+	if len(o.SelectColumns) == 0 {
+		return columns, nil
+	}
+
+	selected := make([]Column, 0, len(o.SelectColumns))
+	for _, name := range o.SelectColumns {
+		idx := slices.IndexFunc(columns, func(col Column) bool {
+			return strings.EqualFold(col.Header, strings.TrimSpace(name))
+		})
+		if idx < 0 {
+			headers := make([]string, len(columns))
+			for i := range columns {
+				headers[i] = columns[i].Header
+			}
+			return nil, fmt.Errorf("unknown column %q, available columns: %s", name, strings.Join(headers, ", "))
+		}
+		selected = append(selected, columns[idx])
+	}
+	return selected, nil
+}
 
-	columns := []Column{}
+// allColumns assembles the full list of columns, in order.
+func (o TablePrinterOptions) allColumns() []Column {
+	columns := slices.Clone(o.PrefixColumns)
+	if len(o.CustomColumns) > 0 {
+		return append(columns, o.CustomColumns...)
+	}
 
-	if p.options.ShowNamespace {
+	if o.ShowNamespace {
 		columns = append(columns, Column{
 			Header: "NAMESPACE",
 			Value: func(obj unstructured.Unstructured) string {
@@ -92,7 +163,7 @@ func (p *TablePrinter) PrintObjects(objects []unstructured.Unstructured, out io.
 		},
 	})
 
-	columns = append(columns, p.options.AdditionalColumns...)
+	columns = append(columns, o.AdditionalColumns...)
 
 	columns = append(columns, Column{
 		Header: "AGE",
@@ -106,23 +177,90 @@ func (p *TablePrinter) PrintObjects(objects []unstructured.Unstructured, out io.
 		},
 	})
 
-	columns = append(columns, p.options.SuffixColumns...)
-	columns = append(columns, p.options.LabelColumns...)
-	columns = append(columns, p.options.AnnotationColumns...)
+	columns = append(columns, o.SuffixColumns...)
+	columns = append(columns, o.LabelColumns...)
+	columns = append(columns, o.AnnotationColumns...)
 
-	headers := make([]string, len(columns))
-	for i := range columns {
-		headers[i] = columns[i].Header
+	return columns
+}
+
+// TableRows collects tables instead of rendering them, so that tables printed separately,
+// e.g. one for every kubeconfig context, can be rendered as one with RenderTables.
+type TableRows struct {
+	tables []collectedTable
+}
+
+type collectedTable struct {
+	headers []string
+	data    [][]string
+}
+
+// renderOrCollect renders the table to out, or collects it when r is set.
+func (r *TableRows) renderOrCollect(out io.Writer, headers []string, data [][]string) error {
+	if r == nil {
+		return RenderTable(out, headers, data)
 	}
+	r.tables = append(r.tables, collectedTable{headers: headers, data: data})
+	return nil
+}
 
-	data := make([][]string, len(objects))
-	for i, obj := range objects {
-		row := make([]string, len(columns))
-		for j, col := range columns {
-			row[j] = col.Value(obj)
+// RenderTables renders tables collected in rows in order, merging tables with the same headers into one,
+// so columns stay aligned across all of them. Tables with other headers are rendered separately.
+func RenderTables(out io.Writer, rows ...*TableRows) error {
+	var merged []collectedTable
+	for _, r := range rows {
+		for _, table := range r.tables {
+			idx := slices.IndexFunc(merged, func(m collectedTable) bool {
+				return slices.Equal(m.headers, table.headers)
+			})
+			if idx < 0 {
+				merged = append(merged, collectedTable{headers: table.headers})
+				idx = len(merged) - 1
+			}
+			merged[idx].data = append(merged[idx].data, table.data...)
+		}
+	}
+	for _, table := range merged {
+		if err := RenderTable(out, table.headers, table.data); err != nil {
+			return err
 		}
-		data[i] = row
 	}
+	return nil
+}
+
+// RenderTable writes headers and rows to out in kubectl-like borderless format.
+func RenderTable(out io.Writer, headers []string, data [][]string) error {
+	table := tablewriter.NewTable(out,
+		// tell render not to render any lines and separators
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
+			Borders: tw.BorderNone,
+			Settings: tw.Settings{
+				Separators: tw.SeparatorsNone,
+				Lines:      tw.LinesNone,
+			},
+		})),
+
+		// Set general configuration
+		tablewriter.WithConfig(
+			tablewriter.Config{
+				Header: tw.CellConfig{
+					Formatting: tw.CellFormatting{
+						Alignment:  tw.AlignLeft, // force alignment for header
+						AutoFormat: tw.Off,
+					},
+					Padding: tw.CellPadding{Global: tw.Padding{Right: "   "}},
+				},
+				Row: tw.CellConfig{
+					Formatting: tw.CellFormatting{
+						Alignment: tw.AlignLeft, // force alightment for body
+					},
+
+					// remove all padding in a in all cells
+					Padding: tw.CellPadding{Global: tw.Padding{Right: "   "}},
+				},
+			},
+		),
+	)
 
 	table.Header(headers)
 	err := table.Bulk(data)
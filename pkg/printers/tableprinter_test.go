@@ -0,0 +1,156 @@
+package printers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestTablePrinter_SelectColumns(t *testing.T) {
+	statusColumn := Column{
+		Header: "STATUS",
+		Value: func(unstructured.Unstructured) string {
+			return "Running"
+		},
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetName("web-1")
+
+	printer := NewTablePrinter(TablePrinterOptions{
+		AdditionalColumns: []Column{statusColumn},
+		SelectColumns:     []string{"status", "Name"},
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{obj}, out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"STATUS", "NAME"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"Running", "web-1"}, strings.Fields(lines[1]))
+}
+
+func TestTablePrinter_HideColumns(t *testing.T) {
+	column := func(header, value string) Column {
+		return Column{
+			Header: header,
+			Value: func(unstructured.Unstructured) string {
+				return value
+			},
+		}
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetName("web-1")
+
+	printer := NewTablePrinter(TablePrinterOptions{
+		AdditionalColumns: []Column{column("READY", "1/1"), column("STATUS", "Running"), column("RESTARTS", "0")},
+		HideColumns:       []string{"ready", " Restarts", "AGE", "NODE"},
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{obj}, out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "STATUS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"web-1", "Running"}, strings.Fields(lines[1]))
+}
+
+func TestTablePrinterOptions_ValidateUnknownColumn(t *testing.T) {
+	options := TablePrinterOptions{
+		SelectColumns: []string{"NAME", "NODE"},
+	}
+
+	err := options.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `unknown column "NODE", available columns: NAME, AGE`, err.Error())
+}
+
+func TestTablePrinter_PrefixColumnsKeptWithCustomColumns(t *testing.T) {
+	contextColumn := Column{
+		Header: "CONTEXT",
+		Value: func(unstructured.Unstructured) string {
+			return "prod"
+		},
+	}
+	nameColumn := Column{
+		Header: "POD",
+		Value: func(obj unstructured.Unstructured) string {
+			return obj.GetName()
+		},
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetName("web-1")
+
+	printer := NewTablePrinter(TablePrinterOptions{
+		PrefixColumns: []Column{contextColumn},
+		CustomColumns: []Column{nameColumn},
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{obj}, out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"CONTEXT", "POD"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"prod", "web-1"}, strings.Fields(lines[1]))
+}
+
+func TestRenderTables_MergesTablesWithSameHeaders(t *testing.T) {
+	contextColumn := func(name string) Column {
+		return Column{
+			Header: "CONTEXT",
+			Value: func(unstructured.Unstructured) string {
+				return name
+			},
+		}
+	}
+	collect := func(contextName string, names ...string) *TableRows {
+		objects := make([]unstructured.Unstructured, len(names))
+		for i, name := range names {
+			objects[i].SetName(name)
+		}
+		rows := &TableRows{}
+		printer := NewTablePrinter(TablePrinterOptions{
+			PrefixColumns: []Column{contextColumn(contextName)},
+			Rows:          rows,
+		})
+		out := &bytes.Buffer{}
+		require.NoError(t, printer.PrintObjects(t.Context(), objects, out))
+		assert.Empty(t, out.String(), "collected rows are not rendered")
+		return rows
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, RenderTables(out, collect("prod", "api", "web"), collect("staging"), collect("dev", "api")))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"CONTEXT", "NAME", "AGE"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"prod", "api", "<unknown>"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"prod", "web", "<unknown>"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"dev", "api", "<unknown>"}, strings.Fields(lines[3]))
+}
+
+func TestTablePrinter_PrintEvent(t *testing.T) {
+	object := func(name string) []unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetName(name)
+		return []unstructured.Unstructured{obj}
+	}
+	printer := NewTablePrinter(TablePrinterOptions{}).(EventPrinter)
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintEvent(t.Context(), watch.Added, object("web-1"), out))
+	require.NoError(t, printer.PrintEvent(t.Context(), watch.Modified, object("web-canary-1"), out))
+	require.NoError(t, printer.PrintEvent(t.Context(), watch.Deleted, object("web-1"), out))
+
+	assert.Equal(t, "EVENT      NAME    AGE         \n"+
+		"ADDED      web-1   <unknown>   \n"+
+		"MODIFIED   web-canary-1   <unknown>   \n"+
+		"DELETED    web-1          <unknown>   \n", out.String(), "the header is printed once and columns grow")
+}
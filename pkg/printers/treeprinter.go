@@ -0,0 +1,148 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+// treeMaxDepth bounds how many owners are walked upward from a matched object.
+const treeMaxDepth = 10
+
+// OwnerGetter fetches the owner referenced by an object.
+type OwnerGetter func(
+	ctx context.Context,
+	obj unstructured.Unstructured,
+	owner metav1.OwnerReference,
+) (*unstructured.Unstructured, error)
+
+type TreePrinterOptions struct {
+	Kind        string // kind of matched objects, used when objects have no kind set (e.g. typed pods)
+	OwnerGetter OwnerGetter
+}
+
+// TreePrinter prints matched objects together with their owners as an indented tree,
+// similar to the kubectl-tree plugin.
+type TreePrinter struct {
+	options TreePrinterOptions
+}
+
+type treeNode struct {
+	label    string
+	parent   *treeNode
+	children []*treeNode
+}
+
+// NewTreePrinter creates a printer that renders the owner hierarchy of matched objects.
+func NewTreePrinter(options TreePrinterOptions) BatchPrinter {
+	return &TreePrinter{
+		options: options,
+	}
+}
+
+func (p *TreePrinter) PrintObjects(ctx context.Context, objects []unstructured.Unstructured, out io.Writer) error {
+	nodes := map[k8s_types.UID]*treeNode{}
+	var roots []*treeNode
+
+	addNode := func(uid k8s_types.UID, label string) *treeNode {
+		node := &treeNode{label: label}
+		nodes[uid] = node
+		roots = append(roots, node)
+		return node
+	}
+	link := func(child, parent *treeNode) {
+		child.parent = parent
+		parent.children = append(parent.children, child)
+		for i, root := range roots {
+			if root == child {
+				roots = append(roots[:i], roots[i+1:]...)
+				break
+			}
+		}
+	}
+
+	for _, obj := range objects {
+		if _, found := nodes[obj.GetUID()]; found {
+			continue // already added as an owner of another object
+		}
+		kind := obj.GetKind()
+		if kind == "" {
+			kind = p.options.Kind
+		}
+		current, currentObj := addNode(obj.GetUID(), kind+"/"+obj.GetName()), obj
+
+		for depth := 0; depth < treeMaxDepth; depth++ {
+			ownerRef := metav1.GetControllerOf(&currentObj)
+			if ownerRef == nil {
+				break
+			}
+			if owner, exists := nodes[ownerRef.UID]; exists {
+				if !isDescendant(owner, current) {
+					link(current, owner)
+				}
+				break
+			}
+			label := ownerRef.Kind + "/" + ownerRef.Name
+			ownerObj, err := p.options.OwnerGetter(ctx, currentObj, *ownerRef)
+			if err != nil {
+				// the owner is gone or not accessible, its ancestors are unknown
+				link(current, addNode(ownerRef.UID, label+" ("+ownerLookupFailure(err)+")"))
+				break
+			}
+			owner := addNode(ownerObj.GetUID(), label)
+			link(current, owner)
+			current, currentObj = owner, *ownerObj
+		}
+	}
+
+	for _, root := range roots {
+		if err := printTreeNode(out, root, "", ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownerLookupFailure describes why an owner could not be fetched, e.g. "forbidden".
+func ownerLookupFailure(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return "not found"
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	default:
+		return "unavailable"
+	}
+}
+
+// isDescendant reports whether node is ancestor itself or one of its descendants,
+// in which case linking them would create a cycle.
+func isDescendant(node, ancestor *treeNode) bool {
+	for ; node != nil; node = node.parent {
+		if node == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func printTreeNode(out io.Writer, node *treeNode, prefix, childPrefix string) error {
+	if _, err := fmt.Fprintln(out, prefix+node.label); err != nil {
+		return fmt.Errorf("failed to write tree: %w", err)
+	}
+	for i, child := range node.children {
+		branch, indent := "├─", "│ "
+		if i == len(node.children)-1 {
+			branch, indent = "└─", "  "
+		}
+		if err := printTreeNode(out, child, childPrefix+branch, childPrefix+indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+func ownedObject(kind, name string, uid k8s_types.UID, owner *unstructured.Unstructured) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetUID(uid)
+	if owner != nil {
+		controller := true
+		obj.SetOwnerReferences([]metav1.OwnerReference{
+			{Kind: owner.GetKind(), Name: owner.GetName(), UID: owner.GetUID(), Controller: &controller},
+		})
+	}
+	return obj
+}
+
+func ownerGetterFor(objects ...unstructured.Unstructured) OwnerGetter {
+	return func(_ context.Context, _ unstructured.Unstructured, ref metav1.OwnerReference) (*unstructured.Unstructured, error) {
+		for i := range objects {
+			if objects[i].GetUID() == ref.UID {
+				return &objects[i], nil
+			}
+		}
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "owners"}, ref.Name)
+	}
+}
+
+func TestTreePrinter_TwoLevelOwnership(t *testing.T) {
+	replicaSet := ownedObject("ReplicaSet", "web-abc", "uid-rs", nil)
+	pod1 := ownedObject("", "web-abc-1", "uid-pod-1", &replicaSet)
+	pod2 := ownedObject("", "web-abc-2", "uid-pod-2", &replicaSet)
+	orphan := ownedObject("", "debug", "uid-pod-3", nil)
+
+	printer := NewTreePrinter(TreePrinterOptions{
+		Kind:        "Pod",
+		OwnerGetter: ownerGetterFor(replicaSet),
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{pod1, pod2, orphan}, out))
+
+	assert.Equal(t, "ReplicaSet/web-abc\n"+
+		"├─Pod/web-abc-1\n"+
+		"└─Pod/web-abc-2\n"+
+		"Pod/debug\n", out.String())
+}
+
+func TestTreePrinter_OwnershipCycle(t *testing.T) {
+	first := ownedObject("ConfigMap", "first", "uid-1", nil)
+	second := ownedObject("ConfigMap", "second", "uid-2", &first)
+	first = ownedObject("ConfigMap", "first", "uid-1", &second)
+
+	printer := NewTreePrinter(TreePrinterOptions{
+		OwnerGetter: ownerGetterFor(first, second),
+	})
+	out := &bytes.Buffer{}
+	require.NoError(t, printer.PrintObjects(t.Context(), []unstructured.Unstructured{first}, out))
+
+	assert.Equal(t, "ConfigMap/second\n"+
+		"└─ConfigMap/first\n", out.String())
+}
+
+func TestTreePrinter_OwnerLookupFailures(t *testing.T) {
+	replicaSet := ownedObject("ReplicaSet", "web-abc", "uid-rs", nil)
+	job := ownedObject("Job", "backup", "uid-job", nil)
+	deleted := ownedObject("", "web-abc-1", "uid-pod-1", &replicaSet)
+	forbidden := ownedObject("", "backup-1", "uid-pod-2", &job)
+	// only the controller is followed, other owners are not part of the tree
+	standalone := ownedObject("", "debug", "uid-pod-3", nil)
+	standalone.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ConfigMap", Name: "settings", UID: "uid-cm"}})
+
+	printer := NewTreePrinter(TreePrinterOptions{
+		Kind: "Pod",
+		OwnerGetter: func(
+			_ context.Context,
+			_ unstructured.Unstructured,
+			ref metav1.OwnerReference,
+		) (*unstructured.Unstructured, error) {
+			if ref.Kind == "Job" {
+				jobs := schema.GroupResource{Group: "batch", Resource: "jobs"}
+				return nil, apierrors.NewForbidden(jobs, ref.Name, nil)
+			}
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "replicasets"}, ref.Name)
+		},
+	})
+	out := &bytes.Buffer{}
+	objects := []unstructured.Unstructured{deleted, forbidden, standalone}
+	require.NoError(t, printer.PrintObjects(t.Context(), objects, out))
+
+	assert.Equal(t, "ReplicaSet/web-abc (not found)\n"+
+		"└─Pod/web-abc-1\n"+
+		"Job/backup (forbidden)\n"+
+		"└─Pod/backup-1\n"+
+		"Pod/debug\n", out.String())
+}
@@ -0,0 +1,32 @@
+package sortby
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// olderThan orders by creation timestamp, oldest first, and objects created in the same second by name.
+func olderThan(a, b metav1.Time, aName, bName string) bool {
+	if !a.Equal(&b) {
+		return a.Before(&b)
+	}
+	return Less(aName, bName)
+}
+
+type UnstructuredByAge []unstructured.Unstructured
+
+func (p UnstructuredByAge) Len() int { return len(p) }
+func (p UnstructuredByAge) Less(i, j int) bool {
+	return olderThan(p[i].GetCreationTimestamp(), p[j].GetCreationTimestamp(), p[i].GetName(), p[j].GetName())
+}
+func (p UnstructuredByAge) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+type PodsByAge []*v1.Pod
+
+func (p PodsByAge) Len() int { return len(p) }
+func (p PodsByAge) Less(i, j int) bool {
+	return olderThan(p[i].CreationTimestamp, p[j].CreationTimestamp, p[i].Name, p[j].Name)
+}
+func (p PodsByAge) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
@@ -0,0 +1,59 @@
+package sortby
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPodsByAge(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	pod := func(name string, age time.Duration) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))}}
+	}
+	pods := []*v1.Pod{pod("new", time.Minute), pod("old-2", time.Hour), pod("mid", 10*time.Minute), pod("old-10", time.Hour)}
+
+	sort.Sort(PodsByAge(pods))
+	assertNames(t, []string{"old-2", "old-10", "mid", "new"}, pods)
+
+	sort.Sort(sort.Reverse(PodsByAge(pods)))
+	assertNames(t, []string{"new", "mid", "old-10", "old-2"}, pods)
+}
+
+func TestUnstructuredByAge(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	object := func(name string, age time.Duration) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetName(name)
+		obj.SetCreationTimestamp(metav1.NewTime(now.Add(-age)))
+		return obj
+	}
+	objects := []unstructured.Unstructured{object("new", time.Minute), object("old", time.Hour), object("mid", 10*time.Minute)}
+
+	sort.Sort(UnstructuredByAge(objects))
+	for i, want := range []string{"old", "mid", "new"} {
+		if objects[i].GetName() != want {
+			t.Fatalf("objects[%d] = %q, want %q", i, objects[i].GetName(), want)
+		}
+	}
+
+	sort.Sort(sort.Reverse(UnstructuredByAge(objects)))
+	for i, want := range []string{"new", "mid", "old"} {
+		if objects[i].GetName() != want {
+			t.Fatalf("reversed objects[%d] = %q, want %q", i, objects[i].GetName(), want)
+		}
+	}
+}
+
+func assertNames(t *testing.T, want []string, pods []*v1.Pod) {
+	t.Helper()
+	for i, name := range want {
+		if pods[i].Name != name {
+			t.Fatalf("pods[%d] = %q, want %q", i, pods[i].Name, name)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package sortby
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodsByRestarts orders pods by the restart count returned by Restarts, most restarted first.
+type PodsByRestarts struct {
+	Pods     []*v1.Pod
+	Restarts func(pod *v1.Pod) int32
+}
+
+func (p PodsByRestarts) Len() int { return len(p.Pods) }
+func (p PodsByRestarts) Less(i, j int) bool {
+	if a, b := p.Restarts(p.Pods[i]), p.Restarts(p.Pods[j]); a != b {
+		return a > b
+	}
+	return Less(p.Pods[i].Name, p.Pods[j].Name)
+}
+func (p PodsByRestarts) Swap(i, j int) { p.Pods[i], p.Pods[j] = p.Pods[j], p.Pods[i] }
+
+// PodsByStatus orders pods by phase, as in the STATUS column.
+type PodsByStatus []*v1.Pod
+
+func (p PodsByStatus) Len() int { return len(p) }
+func (p PodsByStatus) Less(i, j int) bool {
+	if p[i].Status.Phase != p[j].Status.Phase {
+		return p[i].Status.Phase < p[j].Status.Phase
+	}
+	return Less(p[i].Name, p[j].Name)
+}
+func (p PodsByStatus) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
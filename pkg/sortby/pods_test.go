@@ -0,0 +1,54 @@
+package sortby
+
+import (
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podWithStatus(name string, phase v1.PodPhase, restarts ...int32) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: v1.PodStatus{Phase: phase}}
+	for _, count := range restarts {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{RestartCount: count})
+	}
+	return pod
+}
+
+func totalRestarts(pod *v1.Pod) int32 {
+	var total int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		total += cs.RestartCount
+	}
+	return total
+}
+
+func TestPodsByRestarts(t *testing.T) {
+	pods := []*v1.Pod{
+		podWithStatus("stable", v1.PodRunning),
+		podWithStatus("sidecar-crashing", v1.PodRunning, 1, 7),
+		podWithStatus("crashing", v1.PodRunning, 12),
+		podWithStatus("flaky-2", v1.PodRunning, 3),
+		podWithStatus("flaky-10", v1.PodRunning, 3),
+	}
+
+	sort.Sort(PodsByRestarts{Pods: pods, Restarts: totalRestarts})
+	assertNames(t, []string{"crashing", "sidecar-crashing", "flaky-2", "flaky-10", "stable"}, pods)
+
+	sort.Sort(sort.Reverse(PodsByRestarts{Pods: pods, Restarts: totalRestarts}))
+	assertNames(t, []string{"stable", "flaky-10", "flaky-2", "sidecar-crashing", "crashing"}, pods)
+}
+
+func TestPodsByStatus(t *testing.T) {
+	pods := []*v1.Pod{
+		podWithStatus("web", v1.PodRunning),
+		podWithStatus("job", v1.PodSucceeded),
+		podWithStatus("api", v1.PodRunning),
+		podWithStatus("broken", v1.PodFailed),
+		podWithStatus("new", v1.PodPending),
+	}
+
+	sort.Sort(PodsByStatus(pods))
+	assertNames(t, []string{"broken", "new", "api", "web", "job"}, pods)
+}